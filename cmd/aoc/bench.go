@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/puzzle/inputs"
+	"github.com/pkg/profile"
+)
+
+var benchPhases = []string{"parse", "part1", "part2"}
+
+// benchResult summarizes all runs of one phase of a day
+type benchResult struct {
+	Day        int    `json:"day"`
+	Input      string `json:"input"`
+	Phase      string `json:"phase"`
+	Runs       int    `json:"runs"`
+	MinNs      int64  `json:"min_ns"`
+	MeanNs     int64  `json:"mean_ns"`
+	MaxNs      int64  `json:"max_ns"`
+	Allocs     uint64 `json:"allocs"`          // mean per run
+	AllocBytes uint64 `json:"alloc_bytes"`     // mean per run
+	PeakHeap   uint64 `json:"peak_heap_bytes"` // highest of all runs
+}
+
+type benchReport struct {
+	Revision  string        `json:"revision,omitempty"`
+	GoVersion string        `json:"go_version"`
+	Time      time.Time     `json:"time"`
+	Results   []benchResult `json:"results"`
+}
+
+// runs parse, part 1 and part 2 of each day several times, prints a table
+// and optionally writes the results as JSON for comparing commits
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to benchmark, 0 benchmarks all registered days")
+	runs := flags.Int("runs", 5, "number of runs per day")
+	input := flags.String("input", envOr("AOC_INPUT", puzzle.ACTUAL_INPUT), "input to use: test, actual, the name of a custom input or the path of an input file (env AOC_INPUT)")
+	root := flags.String("root", envOr("AOC_ROOT", "."), "repository root holding the dayNN directories (env AOC_ROOT)")
+	jsonFile := flags.String("json", "", "also write the results as JSON to this file, - for stdout")
+	cpuProfile := flags.Bool("cpuprofile", false, "write cpu.pprof to the current directory")
+	flags.Parse(args)
+
+	if *runs < 1 {
+		return fmt.Errorf("runs must be at least 1, got %d", *runs)
+	}
+
+	store, err := openStore(*root)
+	if err != nil {
+		return err
+	}
+
+	days := puzzle.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	if *cpuProfile {
+		defer profile.Start(profile.ProfilePath(".")).Stop()
+	}
+
+	report := benchReport{
+		Revision:  buildRevision(),
+		GoVersion: runtime.Version(),
+		Time:      time.Now().UTC(),
+	}
+	for _, d := range days {
+		results, err := benchDay(store, d, *input, *runs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Day %02d: %v\n", d, err)
+			continue
+		}
+		report.Results = append(report.Results, results...)
+	}
+
+	if *jsonFile != "-" {
+		fmt.Println("Input:\t\t\t", *input)
+		printBenchTable(report.Results)
+	}
+	if *jsonFile != "" {
+		return writeBenchJSON(*jsonFile, report)
+	}
+	return nil
+}
+
+// runs all phases of a day, a panic of the solver is returned as error
+func benchDay(store *inputs.Store, day int, input string, runs int) (results []benchResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	lines, _, kind, err := readInput(store, day, input)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements := make([][]puzzle.Measurement, len(benchPhases))
+	for run := 0; run < runs; run++ {
+		solver, err := newSolver(day, kind, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var parseErr error
+		measurements[0] = append(measurements[0], puzzle.Measure(func() { parseErr = solver.Parse(lines) }))
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		measurements[1] = append(measurements[1], puzzle.Measure(func() { solver.Part1() }))
+		measurements[2] = append(measurements[2], puzzle.Measure(func() { solver.Part2() }))
+	}
+
+	for idx, phase := range benchPhases {
+		results = append(results, summarize(day, input, phase, measurements[idx]))
+	}
+	return results, nil
+}
+
+func summarize(day int, input string, phase string, measurements []puzzle.Measurement) benchResult {
+	result := benchResult{Day: day, Input: input, Phase: phase, Runs: len(measurements)}
+
+	var total time.Duration
+	var allocs, allocBytes uint64
+	for idx, m := range measurements {
+		if idx == 0 || int64(m.Elapsed) < result.MinNs {
+			result.MinNs = int64(m.Elapsed)
+		}
+		if int64(m.Elapsed) > result.MaxNs {
+			result.MaxNs = int64(m.Elapsed)
+		}
+		if m.PeakHeap > result.PeakHeap {
+			result.PeakHeap = m.PeakHeap
+		}
+		total += m.Elapsed
+		allocs += m.Allocs
+		allocBytes += m.AllocBytes
+	}
+
+	runs := uint64(len(measurements))
+	result.MeanNs = int64(total) / int64(runs)
+	result.Allocs = allocs / runs
+	result.AllocBytes = allocBytes / runs
+	return result
+}
+
+func printBenchTable(results []benchResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Day\tPhase\tRuns\tMin\tMean\tMax\tAllocs\tAllocated\tPeak heap\t")
+	for _, r := range results {
+		fmt.Fprintf(w, "%02d\t%s\t%d\t%v\t%v\t%v\t%d\t%s\t%s\t\n", r.Day, r.Phase, r.Runs,
+			time.Duration(r.MinNs), time.Duration(r.MeanNs), time.Duration(r.MaxNs),
+			r.Allocs, formatBytes(r.AllocBytes), formatBytes(r.PeakHeap))
+	}
+	w.Flush()
+}
+
+func writeBenchJSON(filename string, report benchReport) error {
+	data, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if filename == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		value /= unit
+		if value < unit || suffix == "GiB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+	}
+	return ""
+}
+
+// git revision the binary was built from, empty for go run
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
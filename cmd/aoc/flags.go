@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/puzzle/inputs"
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// envOr returns the value of an environment variable or fallback if not set
+func envOr(name string, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return fallback
+}
+
+// settingsFlag collects repeated --set name=value flags
+type settingsFlag puzzle.Settings
+
+func (f settingsFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for name, value := range f {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f settingsFlag) Set(value string) error {
+	name, setting, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %s", value)
+	}
+	f[name] = setting
+	return nil
+}
+
+// newSolver creates the solver of a day and configures its settings for the input kind
+func newSolver(day int, kind string, overrides puzzle.Settings) (puzzle.Solver, error) {
+	solver, err := puzzle.Lookup(day)
+	if err != nil {
+		return nil, err
+	}
+	if err := puzzle.Configure(solver, day, kind, overrides); err != nil {
+		return nil, err
+	}
+	return solver, nil
+}
+
+// openStore creates the input store of the repository at root. With AOC_ARCHIVE
+// inputs missing locally are read from that tarball, with AOC_SESSION actual
+// inputs are downloaded from adventofcode.com. Both are saved below root.
+func openStore(root string) (*inputs.Store, error) {
+	sources := archiveSources()
+	if session := os.Getenv("AOC_SESSION"); session != "" {
+		sources = append(sources, inputs.NewHTTP(session))
+	}
+	return inputs.Repository(root, sources...)
+}
+
+// the archive in AOC_ARCHIVE, if any
+func archiveSources() []inputs.Source {
+	var sources []inputs.Source
+	if archive := os.Getenv("AOC_ARCHIVE"); archive != "" {
+		sources = append(sources, inputs.NewTarball(archive))
+	}
+	return sources
+}
+
+// readInput returns lines, name and kind of an input. test, actual and the
+// names of custom inputs are read from the store, an existing file is read
+// as custom input.
+func readInput(store *inputs.Store, day int, input string) ([]string, string, string, error) {
+	if input == puzzle.TEST_INPUT || input == puzzle.ACTUAL_INPUT {
+		key := inputs.KeyOf(day, input)
+		lines, err := store.Lines(key)
+		return lines, key.String(), input, err
+	}
+	if _, err := os.Stat(input); err == nil {
+		lines, err := utils.ReadDataFile(input)
+		return lines, input, puzzle.CUSTOM_INPUT, err
+	}
+	key := inputs.KeyOf(day, input)
+	lines, err := store.Lines(key)
+	return lines, key.String(), puzzle.CUSTOM_INPUT, err
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/puzzle/inputs"
+)
+
+const (
+	OK      = "OK"
+	CHANGED = "CHANGED"
+	NEW     = "NEW"
+)
+
+// lists the test and actual inputs of all days and the named inputs with
+// answers with the state of their checksum, --update records the checksums
+// of all inputs in inputs.sum
+func inputsCommand(args []string) error {
+	flags := flag.NewFlagSet("inputs", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to list, 0 lists all days that have inputs")
+	root := flags.String("root", envOr("AOC_ROOT", "."), "repository root holding the dayNN directories (env AOC_ROOT)")
+	update := flags.Bool("update", false, "write the checksums of all listed inputs to "+inputs.CHECKSUMS_FILE)
+	flags.Parse(args)
+
+	filename := filepath.Join(*root, inputs.CHECKSUMS_FILE)
+	recorded, err := inputs.LoadChecksums(filename)
+	if err != nil {
+		return err
+	}
+	// only the inputs at hand are listed, nothing is downloaded or written to
+	// the day directories. Without checksums a changed input is reported
+	// instead of failing.
+	dir := inputs.NewDir(*root, inputs.DAY_PATTERN, inputs.RESOURCES_PATTERN)
+	store := inputs.NewStore(append([]inputs.Source{dir}, archiveSources()...)...)
+
+	days := []int{*day}
+	if *day == 0 {
+		days = days[:0]
+		for d := 1; d <= 25; d++ {
+			days = append(days, d)
+		}
+	}
+
+	changed := 0
+	for _, d := range days {
+		answers, err := puzzle.LoadAnswers(*root, d)
+		if err != nil {
+			return err
+		}
+		for _, kind := range answers.Kinds() {
+			key := inputs.KeyOf(d, kind)
+			data, err := store.Bytes(key)
+			if errors.Is(err, inputs.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			checksum := inputs.Checksum(data)
+			status := OK
+			if expected, ok := recorded[key]; !ok {
+				status = NEW
+			} else if expected != checksum {
+				status = CHANGED
+			}
+			if status != OK {
+				changed++
+			}
+			fmt.Printf("%-20s  %-7s  %s\n", key, status, checksum)
+			recorded[key] = checksum
+		}
+	}
+
+	if *update && changed > 0 {
+		fmt.Printf("\nUpdated %d checksums in %s\n", changed, filename)
+		return recorded.Save(filename)
+	}
+	return nil
+}
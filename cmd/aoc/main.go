@@ -0,0 +1,63 @@
+// ---------------------------------------------------------------------------
+// aoc runs the solvers of all days through the puzzle registry
+//
+//	aoc run --day 17 --part 2 --input test
+//	aoc run --day 24 --input my_stress.data --set area_min=7
+//	aoc verify
+//	aoc bench --day 12 --runs 10 --json bench.json
+//	aoc new --day 25
+//	aoc inputs --update
+//
+// Run from the repository root or point --root (AOC_ROOT) to it, the inputs
+// are read through the input store from dayNN/test.data, dayNN/actual.data
+// or the given file.
+// ---------------------------------------------------------------------------
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  run\trun a single day, see aoc run -h")
+	fmt.Fprintln(os.Stderr, "  verify\tcheck all days against their answers.json, see aoc verify -h")
+	fmt.Fprintln(os.Stderr, "  bench\ttime parse, part 1 and part 2 of all days, see aoc bench -h")
+	fmt.Fprintln(os.Stderr, "  settings\tlist the puzzle settings of all days")
+	fmt.Fprintln(os.Stderr, "  new\tcreate a new day from the template, see aoc new -h")
+	fmt.Fprintln(os.Stderr, "  inputs\tlist the inputs of all days and their checksums, see aoc inputs -h")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "verify":
+		err = verifyCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "settings":
+		err = settingsCommand(os.Args[2:])
+	case "new":
+		err = newCommand(os.Args[2:])
+	case "inputs":
+		err = inputsCommand(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
+}
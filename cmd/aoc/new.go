@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+)
+
+const MODULE_PATH = "github.com/cdr74/AdventOfCode2023"
+const TEMPLATE_FILE = "template/main.go"
+const DAYS_FILE = "cmd/aoc/days.go"
+
+// a table driven test of the template solver, cases are added once the parts work
+const testTemplate = `package %[1]s
+
+import "testing"
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		part1 int
+		part2 int
+	}{
+		{"empty", []string{}, 0, 0},
+	}
+	for _, test := range tests {
+		if result := SolvePart1(test.input); result != test.part1 {
+			t.Errorf("%%s part 1: Expected %%d, but got %%d", test.name, test.part1, result)
+		}
+		if result := SolvePart2(test.input); result != test.part2 {
+			t.Errorf("%%s part 2: Expected %%d, but got %%d", test.name, test.part2, result)
+		}
+	}
+}
+`
+
+const benchTemplate = `package %[1]s
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func BenchmarkParse(b *testing.B) { puzzletest.BenchmarkParse(b, %[2]d) }
+func BenchmarkPart1(b *testing.B) { puzzletest.BenchmarkPart(b, %[2]d, 1) }
+func BenchmarkPart2(b *testing.B) { puzzletest.BenchmarkPart(b, %[2]d, 2) }
+`
+
+// solves test and actual input against answers.json, parts without an answer are skipped
+const goldenTemplate = `package %[1]s
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func TestGolden(t *testing.T) { puzzletest.Golden(t, %[2]d) }
+`
+
+// feeds mutations of test.data to Parse, malformed input must not panic
+const fuzzTemplate = `package %[1]s
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func FuzzParse(f *testing.F) { puzzletest.FuzzParse(f, %[2]d) }
+`
+
+const answersTemplate = "{\n\t\"test\": {},\n\t\"actual\": {}\n}\n"
+
+// creates the package of a new day from the template and registers it
+func newCommand(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to create")
+	root := flags.String("root", envOr("AOC_ROOT", "."), "repository root holding the dayNN directories (env AOC_ROOT)")
+	flags.Parse(args)
+
+	if err := newDay(*root, *day); err != nil {
+		return err
+	}
+	fmt.Printf("Created %s, add the input to test.data and actual.data\n", filepath.Join(*root, puzzle.DayDir(*day)))
+	return nil
+}
+
+// newDay checks everything before it writes, an existing day is never changed
+func newDay(root string, day int) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("day must be 1 to 25, got %d", day)
+	}
+	pkg := puzzle.DayDir(day)
+	dir := filepath.Join(root, pkg)
+	if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s exists already, not overwriting it", dir)
+	}
+
+	template, err := os.ReadFile(filepath.Join(root, TEMPLATE_FILE))
+	if err != nil {
+		return err
+	}
+	source, err := fillTemplate(template, day)
+	if err != nil {
+		return err
+	}
+	days, err := os.ReadFile(filepath.Join(root, DAYS_FILE))
+	if err != nil {
+		return err
+	}
+	days, err = registerDay(days, pkg)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"main.go", source},
+		{"main_test.go", []byte(fmt.Sprintf(testTemplate, pkg))},
+		{"bench_test.go", []byte(fmt.Sprintf(benchTemplate, pkg, day))},
+		{"golden_test.go", []byte(fmt.Sprintf(goldenTemplate, pkg, day))},
+		{"fuzz_test.go", []byte(fmt.Sprintf(fuzzTemplate, pkg, day))},
+		{puzzle.ANSWERS_FILE, []byte(answersTemplate)},
+		{puzzle.TEST_INPUT + ".data", nil},
+		{puzzle.ACTUAL_INPUT + ".data", nil},
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file.name), file.content, 0644); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(root, DAYS_FILE), days, 0644)
+}
+
+// fills the day into the header, package name and registration of the template
+func fillTemplate(template []byte, day int) ([]byte, error) {
+	replacer := strings.NewReplacer(
+		"day ..", fmt.Sprintf("day %d", day),
+		"day/..", fmt.Sprintf("day/%d", day),
+		"package template", "package "+puzzle.DayDir(day),
+		"puzzle.Register(0,", fmt.Sprintf("puzzle.Register(%d,", day),
+	)
+	var lines []string
+	for _, line := range strings.Split(string(template), "\n") {
+		// the note how to use the template
+		if strings.HasPrefix(line, "// aoc new") {
+			continue
+		}
+		lines = append(lines, replacer.Replace(line))
+	}
+	source := strings.Join(lines, "\n")
+	if !strings.Contains(source, fmt.Sprintf("puzzle.Register(%d,", day)) {
+		return nil, fmt.Errorf("%s does not register day 0", TEMPLATE_FILE)
+	}
+	return format.Source([]byte(source))
+}
+
+// adds the import of the day package to days.go, imports are kept sorted
+func registerDay(days []byte, pkg string) ([]byte, error) {
+	importLine := fmt.Sprintf("\t_ %q", MODULE_PATH+"/"+pkg)
+	if bytes.Contains(days, []byte(importLine+"\n")) {
+		return nil, fmt.Errorf("%s is registered already", pkg)
+	}
+
+	lines := strings.Split(string(days), "\n")
+	var imports []int
+	for idx, line := range lines {
+		if strings.HasPrefix(line, "\t_ \"") {
+			imports = append(imports, idx)
+		}
+	}
+	if len(imports) == 0 {
+		return nil, fmt.Errorf("no imports in %s", DAYS_FILE)
+	}
+
+	block := []string{importLine}
+	for _, idx := range imports {
+		block = append(block, lines[idx])
+	}
+	sort.Strings(block)
+
+	first, last := imports[0], imports[len(imports)-1]
+	result := append([]string{}, lines[:first]...)
+	result = append(result, block...)
+	result = append(result, lines[last+1:]...)
+	return format.Source([]byte(strings.Join(result, "\n")))
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// copies the files newDay reads into an empty repository root
+func newTestRoot(t *testing.T) string {
+	root := t.TempDir()
+	for _, name := range []string{TEMPLATE_FILE, DAYS_FILE} {
+		data, err := os.ReadFile(filepath.Join("..", "..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestNewDay(t *testing.T) {
+	root := newTestRoot(t)
+	if err := newDay(root, 25); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	for _, name := range []string{"main.go", "main_test.go", "bench_test.go", "golden_test.go", "fuzz_test.go", "answers.json", "test.data", "actual.data"} {
+		if _, err := os.Stat(filepath.Join(root, "day25", name)); err != nil {
+			t.Errorf("Expected day25/%s, but got %v", name, err)
+		}
+	}
+
+	source, _ := os.ReadFile(filepath.Join(root, "day25", "main.go"))
+	for _, expected := range []string{"day 25\n", "day/25\n", "package day25\n", "puzzle.Register(25,"} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("Expected main.go to contain %q", expected)
+		}
+	}
+	if strings.Contains(string(source), "aoc new") {
+		t.Errorf("Expected the template note to be removed")
+	}
+
+	days, _ := os.ReadFile(filepath.Join(root, DAYS_FILE))
+	if !strings.Contains(string(days), "day24\"\n\t_ \"github.com/cdr74/AdventOfCode2023/day25\"\n)") {
+		t.Errorf("Expected day25 to be imported after day24, but got\n%s", days)
+	}
+}
+
+func TestNewDayRefusesToOverwrite(t *testing.T) {
+	root := newTestRoot(t)
+	if err := os.Mkdir(filepath.Join(root, "day13"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	days, _ := os.ReadFile(filepath.Join(root, DAYS_FILE))
+
+	tests := []int{13, 24, 0, 26}
+	for _, day := range tests {
+		if err := newDay(root, day); err == nil {
+			t.Errorf("Expected an error for day %d", day)
+		}
+	}
+
+	// a day registered without its directory is not registered twice
+	if err := newDay(root, 12); err == nil || !strings.Contains(err.Error(), "registered already") {
+		t.Errorf("Expected day12 to be registered already, but got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "day12")); err == nil {
+		t.Errorf("Expected no day12 directory after the error")
+	}
+	if unchanged, _ := os.ReadFile(filepath.Join(root, DAYS_FILE)); string(unchanged) != string(days) {
+		t.Errorf("Expected %s to be unchanged", DAYS_FILE)
+	}
+}
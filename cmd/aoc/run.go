@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/pkg/profile"
+)
+
+// runs parse and one or both parts of a day, prints results like the
+// former main() of each day did
+func runCommand(args []string) error {
+	overrides := make(settingsFlag)
+
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to run")
+	part := flags.Int("part", 0, "part to run, 0 runs both parts")
+	input := flags.String("input", envOr("AOC_INPUT", puzzle.ACTUAL_INPUT), "input to use: test, actual, the name of a custom input or the path of an input file (env AOC_INPUT)")
+	root := flags.String("root", envOr("AOC_ROOT", "."), "repository root holding the dayNN directories (env AOC_ROOT)")
+	cpuProfile := flags.Bool("cpuprofile", false, "write cpu.pprof to the current directory")
+	timing := flags.String("timing", "", "print the time of parse and each part: pretty or json")
+	flags.Var(overrides, "set", "override a puzzle setting as name=value, can be repeated (env AOC_DAYNN_NAME)")
+	flags.Parse(args)
+
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("part must be 1 or 2, got %d", *part)
+	}
+	if *timing != "" && *timing != "pretty" && *timing != "json" {
+		return fmt.Errorf("timing must be pretty or json, got %s", *timing)
+	}
+
+	store, err := openStore(*root)
+	if err != nil {
+		return err
+	}
+
+	if *cpuProfile {
+		defer profile.Start(profile.ProfilePath(".")).Stop()
+	}
+
+	stopwatch := utils.NewStopwatch()
+	stopwatch.Start()
+
+	lines, name, kind, err := readInput(store, *day, *input)
+	if err != nil {
+		return err
+	}
+	solver, err := newSolver(*day, kind, puzzle.Settings(overrides))
+	if err != nil {
+		return err
+	}
+	parse := stopwatch.Lap("parse")
+	err = solver.Parse(lines)
+	parse.Stop()
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	var result1, result2 any
+	if *part != 2 {
+		stopwatch.Time("part1", func() { result1 = solver.Part1() })
+	}
+	if *part != 1 {
+		stopwatch.Time("part2", func() { result2 = solver.Part2() })
+	}
+	stopwatch.Stop()
+
+	// ---------------------- Print results ----------------------------------
+	fmt.Println("Day:\t\t\t", *day)
+	fmt.Println("Input:\t\t\t", name)
+	if *part != 2 {
+		fmt.Println("Result 1:\t\t", result1)
+	}
+	if *part != 1 {
+		fmt.Println("Result 2:\t\t", result2)
+	}
+	fmt.Println("Elapsed time:\t\t", stopwatch.GetElapsedTime())
+
+	switch *timing {
+	case "pretty":
+		fmt.Println()
+		stopwatch.PrintSummary(os.Stdout)
+	case "json":
+		return stopwatch.WriteJSON(os.Stdout)
+	}
+	return nil
+}
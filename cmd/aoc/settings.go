@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+)
+
+// lists the puzzle settings of all days with their defaults
+func settingsCommand(args []string) error {
+	flags := flag.NewFlagSet("settings", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to list, 0 lists all registered days")
+	flags.Parse(args)
+
+	days := puzzle.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	for _, d := range days {
+		solver, err := puzzle.Lookup(d)
+		if err != nil {
+			return err
+		}
+		configurable, ok := solver.(puzzle.Configurable)
+		if !ok {
+			continue
+		}
+		for _, setting := range configurable.Settings() {
+			fmt.Printf("Day %02d  %s\n", d, setting.Name)
+			fmt.Printf("\t%s\n", setting.Usage)
+			fmt.Printf("\ttest: %s, actual: %s, env: %s\n", setting.Test, setting.Actual, puzzle.EnvName(d, setting.Name))
+		}
+	}
+	return nil
+}
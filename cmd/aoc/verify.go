@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/puzzle/inputs"
+)
+
+const (
+	PASS    = "PASS"
+	FAIL    = "FAIL"
+	MISSING = "MISSING"
+)
+
+var errTimeout = errors.New("timeout")
+
+type verifyCount struct {
+	passed  int
+	failed  int
+	missing int
+}
+
+// runs every registered solver on test and actual input and the named inputs
+// with answers and compares the results with dayNN/answers.json
+func verifyCommand(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to verify, 0 verifies all registered days")
+	root := flags.String("root", envOr("AOC_ROOT", "."), "repository root holding the dayNN directories (env AOC_ROOT)")
+	timeout := flags.Duration("timeout", 2*time.Minute, "time limit for parse and each part")
+	strict := flags.Bool("strict", false, "treat missing answers as failures")
+	flags.Parse(args)
+
+	days := puzzle.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	store, err := openStore(*root)
+	if err != nil {
+		return err
+	}
+
+	var count verifyCount
+	for _, d := range days {
+		answers, err := puzzle.LoadAnswers(*root, d)
+		if err != nil {
+			return err
+		}
+		for _, kind := range answers.Kinds() {
+			verifyInput(store, d, kind, answers[kind], *timeout, &count)
+		}
+	}
+
+	fmt.Printf("\nPassed: %d, Failed: %d, Missing: %d\n", count.passed, count.failed, count.missing)
+	if count.failed > 0 || (*strict && count.missing > 0) {
+		return fmt.Errorf("verify failed")
+	}
+	return nil
+}
+
+func verifyInput(store *inputs.Store, day int, kind string, expected puzzle.Expected, timeout time.Duration, count *verifyCount) {
+	report := func(part int, status string, detail string) {
+		switch status {
+		case PASS:
+			count.passed++
+		case FAIL:
+			count.failed++
+		case MISSING:
+			count.missing++
+		}
+		fmt.Printf("Day %02d  %-8s  part %d  %-7s  %s\n", day, kind, part, status, detail)
+	}
+
+	key := inputs.KeyOf(day, kind)
+	lines, err := store.Lines(key)
+	if errors.Is(err, inputs.ErrNotFound) {
+		report(1, MISSING, "no input "+key.String())
+		report(2, MISSING, "no input "+key.String())
+		return
+	}
+
+	var solver puzzle.Solver
+	if err == nil {
+		solver, err = newSolver(day, kind, nil)
+	}
+	if err == nil {
+		_, err = callWithTimeout(timeout, func() (any, error) {
+			return nil, solver.Parse(lines)
+		})
+	}
+	if err != nil {
+		report(1, FAIL, "parse: "+err.Error())
+		report(2, FAIL, "parse: "+err.Error())
+		return
+	}
+
+	parts := []func() any{solver.Part1, solver.Part2}
+	for idx, solve := range parts {
+		part := idx + 1
+		result, err := callWithTimeout(timeout, func() (any, error) {
+			result := solve()
+			if err, ok := result.(error); ok {
+				return nil, err
+			}
+			return result, nil
+		})
+		if errors.Is(err, errTimeout) {
+			report(part, FAIL, err.Error())
+			// the part still running in the background may share state with the next
+			for part++; part <= len(parts); part++ {
+				report(part, FAIL, "skipped")
+			}
+			return
+		}
+		if err != nil {
+			report(part, FAIL, err.Error())
+			continue
+		}
+
+		got := puzzle.FormatResult(result)
+		switch want := expected.Part(part); {
+		case want == "":
+			report(part, MISSING, "got "+got)
+		case want == got:
+			report(part, PASS, got)
+		default:
+			report(part, FAIL, fmt.Sprintf("got %s, want %s", got, want))
+		}
+	}
+}
+
+// calls fn in its own goroutine and turns a panic into an error. A solver that
+// exceeds the timeout can not be stopped, it keeps running until aoc exits.
+func callWithTimeout(timeout time.Duration, fn func() (any, error)) (any, error) {
+	type outcome struct {
+		result any
+		err    error
+	}
+	done := make(chan outcome, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		result, err := fn()
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("%w after %v", errTimeout, timeout)
+	}
+}
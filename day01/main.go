@@ -1,20 +1,13 @@
 // https://adventofcode.com/2023/day/1
-package main
+package day01
 
 import (
-	"fmt"
 	"regexp"
 	"strconv"
-	"time"
 
-	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 )
 
-// the flag runTest defines which data file to read
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // ---------------------------------------------------------------------------
 
 func charToNumber(char rune) int {
@@ -105,37 +98,32 @@ func SolvePuzzlePart2(input []string) int {
 		firstMatch, lastMatch := findFirstAndLastMatch(line, patterns)
 		firstValue := getValue(patterns, firstMatch)
 		lastValue := getValue(patterns, lastMatch)
-		fmt.Printf("String: %s - First: %v - Last: %v\n", line, firstValue, lastValue)
+		//fmt.Printf("String: %s - First: %v - Last: %v\n", line, firstValue, lastValue)
 		summ += firstValue*10 + lastValue
 	}
 
 	return summ
 }
 
-func main() {
-	var result1 int
-	var result2 int
-
-	var input []string
+// ---------------------------------------------------------------------------
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func init() {
+	puzzle.Register(1, func() puzzle.Solver { return &solver{} })
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+type solver struct {
+	input []string
+}
 
-	result1 = SolvePuzzlePart1(input)
-	result2 = SolvePuzzlePart2(input)
+func (s *solver) Parse(input []string) error {
+	s.input = input
+	return nil
+}
 
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePuzzlePart1(s.input)
+}
 
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Printf("Result:\t\t\t%d\n", result1)
-	fmt.Printf("Result:\t\t\t%d\n", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzlePart2(s.input)
 }
@@ -1,19 +1,10 @@
-package main
+package day02
 
 import (
-	"fmt"
-	"strconv"
-	"strings"
-	"time"
-
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
 )
 
-// the flag runTest defines which data file to read
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // ---------------------------------------------------------------------------
 
 type Bag struct {
@@ -27,16 +18,22 @@ type Game struct {
 	bags []Bag
 }
 
+var colorFormat = utils.MustLineFormat("%d %s")
+var gameFormat = utils.MustLineFormat("Game %d: %s")
+
 // Input looks like "6 red, 1 blue, 3 green" each color is optional, set 0 if not present
-func stringToBag(input string) Bag {
+func stringToBag(input utils.Line) (Bag, error) {
 	var bag Bag = Bag{RedItems: 0, BlueItems: 0, GreenItems: 0}
 
-	colors := strings.Split(input, ",")
+	colors := input.Split(",")
 	for _, color := range colors {
-		color = strings.Trim(color, " ")
-		parts := strings.Split(color, " ")
-		value, _ := strconv.Atoi(parts[0])
-		switch parts[1] {
+		var value int
+		var name string
+		color = color.TrimSpace()
+		if err := colorFormat.Scan(color, &value, &name); err != nil {
+			return bag, err
+		}
+		switch name {
 		case "red":
 			bag.RedItems = value
 		case "blue":
@@ -44,46 +41,49 @@ func stringToBag(input string) Bag {
 		case "green":
 			bag.GreenItems = value
 		default:
-			panic("Color not defined [" + parts[1] + "]")
+			return bag, color.ErrorfAt(len(color.Text)-len(name), "color not defined [%s]", name)
 		}
 	}
-	return bag
+	return bag, nil
 }
 
 // Turns an input line into a Game type. Each input line looks like
 // Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green
 // There's always a prefix of "Game x:" Color sets are seperated by;
 // Any color is optional
-func stringToToGame(input string) Game {
+func stringToToGame(line utils.Line) (Game, error) {
 	var game Game
+	var draws string
 
-	spaceIndex := strings.Index(input, " ")
-	colonIndex := strings.Index(input, ":")
-	numberSubstring := input[spaceIndex+1 : colonIndex]
-	game.ID, _ = strconv.Atoi(numberSubstring)
-
-	// ignore first part with game id, read colors
-	input = input[colonIndex+1:]
+	if err := gameFormat.Scan(line, &game.ID, &draws); err != nil {
+		return game, err
+	}
 
 	// iterate over all bag draws in a game
-	gameStrings := strings.Split(input, ";")
-	for _, gameString := range gameStrings {
-		bag := stringToBag(gameString)
+	drawsLine := line.Slice(len(line.Text)-len(draws), len(line.Text))
+	for _, gameString := range drawsLine.Split(";") {
+		bag, err := stringToBag(gameString)
+		if err != nil {
+			return game, err
+		}
 		game.bags = append(game.bags, bag)
 	}
-	return game
+	return game, nil
 }
 
 // Iterate over input file represented by list of strings.
 // returns a list of Games
-func inputToGames(input []string) []Game {
+func inputToGames(input []string) ([]Game, error) {
 	var games []Game
-	for _, line := range input {
-		gamesOfLine := stringToToGame(line)
+	for idx, line := range input {
+		gamesOfLine, err := stringToToGame(utils.NewLine(idx, line))
+		if err != nil {
+			return nil, err
+		}
 		games = append(games, gamesOfLine)
 	}
 
-	return games
+	return games, nil
 }
 
 // ---------------------------------------------------------------------------
@@ -144,27 +144,24 @@ func SolvePuzzle2(games []Game) int {
 
 // ---------------------------------------------------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(2, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	games []Game
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.games, err = inputToGames(input)
+	return err
+}
+
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.games)
+}
 
-	games := inputToGames(input)
-	result1 := SolvePuzzle1(games)
-	result2 := SolvePuzzle2(games)
-	stopwatch.Stop()
-
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t\t", result1)
-	fmt.Println("Result 2:\t\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.games)
 }
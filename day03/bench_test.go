@@ -0,0 +1,11 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func BenchmarkParse(b *testing.B) { puzzletest.BenchmarkParse(b, 3) }
+func BenchmarkPart1(b *testing.B) { puzzletest.BenchmarkPart(b, 3, 1) }
+func BenchmarkPart2(b *testing.B) { puzzletest.BenchmarkPart(b, 3, 2) }
@@ -0,0 +1,9 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func TestGolden(t *testing.T) { puzzletest.Golden(t, 3) }
@@ -1,65 +1,64 @@
-package main
+package day03
 
 import (
-	"fmt"
-	"math"
-
-	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// the flag runTest defines which data file to read
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
+// Digits remain digits, anything else is one of these
+const (
+	EMPTY  = -1
+	SYMBOL = -2
+	GEAR   = -3 // Part 2
+)
 
 // ---------------------------------------------------------------------------
 
-// Translates strings like "467..114.#" into an int array.
-// Digits remain digits, . = -1; Anything else = -2
-// Part 2 Gears are -3
-// we add a borer of -1
-func inputToArray(input []string) [][]int {
-	width := len(input[0]) + 2
-	depth := len(input) + 2
-	dataArr := utils.CreateIntArray(width, depth, -1)
-
-	for d, row := range input {
-		for w, char := range row {
-			switch char {
-			case '.':
-				// already initialized, no action needed
-			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-				dataArr[d+1][w+1] = int(char - '0')
-			case '*':
-				dataArr[d+1][w+1] = -3
-			default:
-				dataArr[d+1][w+1] = -2
-			}
+// Translates strings like "467..114.#" into a grid of ints.
+func inputToGrid(input []string) (*grid.Grid[int], error) {
+	return grid.Parse(input, func(char byte) (int, error) {
+		switch {
+		case char == '.':
+			return EMPTY, nil
+		case char >= '0' && char <= '9':
+			return int(char - '0'), nil
+		case char == '*':
+			return GEAR, nil
 		}
-	}
-
-	return dataArr
+		return SYMBOL, nil
+	})
 }
 
-// ---------------------------------------------------------------------------
+func isDigit(data *grid.Grid[int], p grid.Point) bool {
+	value, ok := data.Get(p)
+	return ok && value >= 0
+}
 
-// starts with rightmost digit, work to left until no digit found
-func getNumber(row []int, w int) (int, int) {
-	result := row[w]
-	len := 1
+// walks left from a digit to the first digit of its number
+func findStartOfNumber(data *grid.Grid[int], p grid.Point) grid.Point {
+	for isDigit(data, p.Add(grid.West)) {
+		p = p.Add(grid.West)
+	}
+	return p
+}
 
-	for pos := w - 1; pos >= 1 && row[pos] >= 0; pos-- {
-		result += int(math.Pow10(len)) * row[pos]
+// reads the number starting at p, returns it and its length
+func getNumber(data *grid.Grid[int], p grid.Point) (int, int) {
+	result := 0
+	len := 0
+	for ; isDigit(data, p); p = p.Add(grid.East) {
+		result = result*10 + data.At(p)
 		len++
 	}
-
 	return result, len
 }
 
-func isIsolated(data [][]int, d int, w int, len int) bool {
-	for y := d - 1; y <= d+1; y++ {
-		for x := w - len; x <= w+1; x++ {
-			if data[y][x] < -1 {
+// ---------------------------------------------------------------------------
+
+func isIsolated(data *grid.Grid[int], start grid.Point, len int) bool {
+	for p := start; p.Col < start.Col+len; p = p.Add(grid.East) {
+		for _, n := range data.Neighbors8(p) {
+			if data.At(n) < EMPTY {
 				return false
 			}
 		}
@@ -67,17 +66,18 @@ func isIsolated(data [][]int, d int, w int, len int) bool {
 	return true
 }
 
-func SolvePuzzle1(data [][]int) int {
+func SolvePuzzle1(data *grid.Grid[int]) int {
 	var result int = 0
 
-	for d := 1; d < len(data)-1; d++ {
-		for w := len(data[0]) - 1; w >= 1; w-- {
-			if data[d][w] >= 0 {
-				number, len := getNumber(data[d], w)
-				if !isIsolated(data, d, w, len) {
+	for row := 0; row < data.Rows(); row++ {
+		for col := 0; col < data.Cols(); col++ {
+			p := grid.Point{Row: row, Col: col}
+			if isDigit(data, p) {
+				number, len := getNumber(data, p)
+				if !isIsolated(data, p, len) {
 					result += number
 				}
-				w -= len
+				col += len
 			}
 		}
 	}
@@ -87,84 +87,33 @@ func SolvePuzzle1(data [][]int) int {
 
 // ---------------------------------------------------------------------------
 
-// searches start of digits to the right
-func findStartOfNumber(row []int, start int) int {
-	for idx := start; idx < len(row); idx++ {
-		if row[idx] < 0 {
-			return idx - 1
-		}
-	}
-	panic("Failed to find start of Number")
-}
-
-// Checks 3 fields for a positive number and then identifies the actual number
-func nbrsOfRow(row []int, start int) []int {
-	var results []int
-	a := row[start]
-	b := row[start+1]
-	c := row[start+2]
-
-	switch {
-	case a >= 0 && b >= 0 && c >= 0:
-		idx := findStartOfNumber(row, start)
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-	case a >= 0 && b < 0 && c >= 0:
-		idx := start
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-		idx = findStartOfNumber(row, start+2)
-		number2, _ := getNumber(row, idx)
-		results = append(results, number2)
-	case a >= 0 && b < 0 && c < 0:
-		idx := start
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-	case a < 0 && b < 0 && c >= 0:
-		idx := findStartOfNumber(row, start+2)
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-	case a < 0 && b >= 0 && c >= 0:
-		idx := findStartOfNumber(row, start+2)
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-	case a >= 0 && b >= 0 && c < 0:
-		idx := start + 1
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-	case a < 0 && b >= 0 && c < 0:
-		idx := start + 1
-		number, _ := getNumber(row, idx)
-		results = append(results, number)
-	}
-	return results
-}
-
-func hasTwoNumbers(data [][]int, d int, w int) (bool, int) {
+// collects the distinct numbers around a gear
+func adjacentNumbers(data *grid.Grid[int], gear grid.Point) []int {
 	var numbers []int
-	numbers = append(numbers, nbrsOfRow(data[d-1], w-1)...)
-	numbers = append(numbers, nbrsOfRow(data[d], w-1)...)
-	numbers = append(numbers, nbrsOfRow(data[d+1], w-1)...)
-	if len(numbers) == 2 {
-		//fmt.Printf("hasTwoNumbers>> %v\n", numbers)
-		return true, numbers[0] * numbers[1]
+	seen := make(map[grid.Point]bool)
+	for _, n := range data.Neighbors8(gear) {
+		if !isDigit(data, n) {
+			continue
+		}
+		start := findStartOfNumber(data, n)
+		if !seen[start] {
+			seen[start] = true
+			number, _ := getNumber(data, start)
+			numbers = append(numbers, number)
+		}
 	}
-	return false, 0
+	return numbers
 }
 
-func SolvePuzzle2(data [][]int) int {
+func SolvePuzzle2(data *grid.Grid[int]) int {
 	var result int = 0
 
-	for d := 1; d < len(data)-1; d++ {
-		for w := 1; w < len(data[0]); w++ {
-			if (data[d][w]) == -3 {
-				// found a star
-				hit, power := hasTwoNumbers(data, d, w)
-				if hit {
-					//fmt.Printf("Found engine part at %d, %d - %d\n", d, w, power)
-					result += power
-				}
-			}
+	isGear := func(value int) bool { return value == GEAR }
+	for _, gear := range data.FindAll(isGear) {
+		numbers := adjacentNumbers(data, gear)
+		if len(numbers) == 2 {
+			//fmt.Printf("Found engine part at %v - %d\n", gear, numbers[0]*numbers[1])
+			result += numbers[0] * numbers[1]
 		}
 	}
 	return result
@@ -172,27 +121,24 @@ func SolvePuzzle2(data [][]int) int {
 
 // ---------------------------------------------------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(3, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	data *grid.Grid[int]
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.data, err = inputToGrid(input)
+	return err
+}
+
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.data)
+}
 
-	dataArr := inputToArray(input)
-	result1 := SolvePuzzle1(dataArr)
-	result2 := SolvePuzzle2(dataArr)
-	stopwatch.Stop()
-
-	// -------------------------------------
-	elapsedTime := stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.data)
 }
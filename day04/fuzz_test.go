@@ -0,0 +1,9 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func FuzzParse(f *testing.F) { puzzletest.FuzzParse(f, 4) }
@@ -1,54 +1,24 @@
-package main
+package day04
 
 import (
-	"fmt"
-	"strings"
-	"time"
-
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
 )
 
-// the flag runTest defines which data file to read
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // ---------------------------------------------------------------------------
 
+// Input format: Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
 type Ticket struct {
-	ID           int
-	LuckyNumbers []int
-	DrawnNumbers []int
+	ID           int   `parse:"Card %d: "`
+	LuckyNumbers []int `parse:"%s | "`
+	DrawnNumbers []int `parse:"%s"`
 }
 
-func NewTicket(input string) Ticket {
+func NewTicket(line utils.Line) (Ticket, error) {
 	var ticket Ticket
-
-	idx1 := strings.IndexRune(input, ':')
-	idx2 := strings.IndexRune(input, '|')
-
-	ticket.ID = utils.StringToInt(strings.Trim(input[5:idx1], " "))
-
-	numbers1 := strings.Trim(input[idx1+1:idx2], " ")
-	numbers2 := strings.Trim(input[idx2+1:], " ")
-
-	numbers1List := strings.Split(numbers1, " ")
-	numbers2List := strings.Split(numbers2, " ")
-
-	for _, number := range numbers1List {
-		if number != "" {
-			ticket.LuckyNumbers = append(ticket.LuckyNumbers, utils.StringToInt(strings.Trim(number, " ")))
-		}
-	}
-
-	for _, number := range numbers2List {
-		if number != "" {
-			ticket.DrawnNumbers = append(ticket.DrawnNumbers, utils.StringToInt(strings.Trim(number, " ")))
-		}
-	}
-
+	err := utils.ScanStruct(line, &ticket)
 	//fmt.Printf("Ticket %d: %v\n", ticket.ID, ticket)
-	return ticket
+	return ticket, err
 }
 
 func containsValue(list []int, value int) bool {
@@ -125,31 +95,29 @@ func SolvePuzzle2(tickets []Ticket) int {
 
 // ---------------------------------------------------------------------------
 
-func main() {
-	var input []string
-	var tickets []Ticket
-
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func init() {
+	puzzle.Register(4, func() puzzle.Solver { return &solver{} })
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+type solver struct {
+	tickets []Ticket
+}
 
-	for _, line := range input {
-		tickets = append(tickets, NewTicket(line))
+func (s *solver) Parse(input []string) error {
+	for idx, line := range input {
+		ticket, err := NewTicket(utils.NewLine(idx, line))
+		if err != nil {
+			return err
+		}
+		s.tickets = append(s.tickets, ticket)
 	}
+	return nil
+}
 
-	result1 := SolvePuzzle1(tickets)
-	result2 := SolvePuzzle2(tickets)
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.tickets)
+}
 
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t\t", result1)
-	fmt.Println("Result 2:\t\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.tickets)
 }
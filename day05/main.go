@@ -1,73 +1,75 @@
-package main
+package day05
 
 import (
 	"fmt"
 	"math"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
 )
 
-// the flag runTest defines which data file to read
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // ---------------------------------------------------------------------------
 
+// seed-to-soil up to humidity-to-location
+const MAPPING_LEVELS int = 7
+
+// Mapping moves the values of source by offset, "50 98 2" maps 98..99 to 50..51
 type Mapping struct {
-	targetStart uint64
-	sourceStart uint64
-	sourceEnd   uint64
-	length      uint64
+	source utils.Interval
+	offset int
 }
 
 type MappingList struct {
 	mapping [][]Mapping
 }
 
-type Seed struct {
-	start  uint64
-	length uint64
-}
-
-func createMappings(input []string) MappingList {
+// each section is one map like "seed-to-soil map:" followed by lines of 3 numbers
+func createMappings(sections []utils.Section) (MappingList, error) {
 	var mappings MappingList
-	var currentMapping []Mapping
 
-	for _, line := range input {
+	if len(sections) != MAPPING_LEVELS {
+		return mappings, fmt.Errorf("expected %d maps, got %d", MAPPING_LEVELS, len(sections))
+	}
 
-		if strings.HasSuffix(line, ":") {
-			mappings.mapping = append(mappings.mapping, currentMapping)
-			currentMapping = nil
-			continue
+	for _, section := range sections {
+		if !strings.HasSuffix(section.Header, " map:") {
+			return mappings, section.HeaderLine().Errorf("expected header like \"seed-to-soil map:\"")
 		}
 
-		if line == "" {
-			continue
-		}
+		var currentMapping []Mapping
+		for idx := range section.Lines {
+			context := section.Line(idx)
+			numbersList, err := utils.Ints[int](context)
+			if err != nil {
+				return mappings, err
+			}
+			if len(numbersList) != 3 {
+				return mappings, context.Errorf("expected 3 numbers, got %d", len(numbersList))
+			}
+			source, okSource := utils.IntervalOf(numbersList[1], numbersList[2])
+			destination, okDestination := utils.IntervalOf(numbersList[0], numbersList[2])
+			if !okSource || !okDestination {
+				return mappings, context.Errorf("range of %d values does not fit into an int", numbersList[2])
+			}
+			mapping := Mapping{source: source, offset: numbersList[0] - numbersList[1]}
+			// the offset wraps around if the ranges are too far apart
+			if shifted, ok := source.Shift(mapping.offset); !ok || shifted != destination {
+				return mappings, context.Errorf("offset from %d to %d does not fit into an int", numbersList[1], numbersList[0])
+			}
 
-		numbersList := strings.Fields(line)
-		mapping := Mapping{
-			targetStart: utils.StringToUint64(numbersList[0]),
-			sourceStart: utils.StringToUint64(numbersList[1]),
-			length:      utils.StringToUint64(numbersList[2]),
-			sourceEnd:   utils.StringToUint64(numbersList[1]) + utils.StringToUint64(numbersList[2]),
+			currentMapping = append(currentMapping, mapping)
 		}
-
-		currentMapping = append(currentMapping, mapping)
+		mappings.mapping = append(mappings.mapping, currentMapping)
 	}
-	mappings.mapping = append(mappings.mapping, currentMapping)
-	return mappings
+	return mappings, nil
 }
 
-func applyMapping(position uint64, mappings []Mapping) uint64 {
+func applyMapping(position int, mappings []Mapping) int {
 	for _, mapping := range mappings {
-		if position >= mapping.sourceStart && position <= mapping.sourceEnd {
-			delta := position - mapping.sourceStart
-			//fmt.Printf("applyMapping() - %v -> %v -> %v\n", position, mappings, mapping.targetStart+delta)
-			return mapping.targetStart + delta
+		if mapping.source.Contains(position) {
+			//fmt.Printf("applyMapping() - %v -> %v -> %v\n", position, mappings, position+mapping.offset)
+			return position + mapping.offset
 		}
 	}
 	// no mapping keep as is
@@ -75,27 +77,47 @@ func applyMapping(position uint64, mappings []Mapping) uint64 {
 	return position
 }
 
-// ---------------------------------------------------------------------------
-
-func getSeeds(input string) []uint64 {
-	var seeds []uint64
-	numbersList := strings.Split(input, " ")
-	for _, number := range numbersList {
-		if number != "" {
-			seeds = append(seeds, utils.StringToUint64(strings.Trim(number, " ")))
+// maps all values of the set at once, each interval is split by the sources
+// of the mappings, the parts not covered by any mapping are kept as is
+func applyMappingToSet(positions utils.IntervalSet, mappings []Mapping) utils.IntervalSet {
+	var result utils.IntervalSet
+	for _, interval := range positions.Intervals() {
+		unmapped := []utils.Interval{interval}
+		for _, mapping := range mappings {
+			var rest []utils.Interval
+			for _, part := range unmapped {
+				inside, outside := part.Split(mapping.source)
+				if !inside.Empty() {
+					// fits, the destination range was checked when parsing
+					shifted, _ := inside.Shift(mapping.offset)
+					result = result.Add(shifted)
+				}
+				rest = append(rest, outside...)
+			}
+			unmapped = rest
+		}
+		for _, part := range unmapped {
+			result = result.Add(part)
 		}
 	}
-	fmt.Printf("getSeeds() - %v\n", seeds)
-	return seeds
+	return result
 }
 
-func SolvePuzzle1(seeds []uint64, mappings MappingList) uint64 {
-	var result uint64 = math.MaxUint64
-	var position uint64 = 0
+// ---------------------------------------------------------------------------
+
+func getSeeds(line utils.Line) ([]int, error) {
+	seeds, err := utils.Ints[int](line)
+	//fmt.Printf("getSeeds() - %v\n", seeds)
+	return seeds, err
+}
+
+func SolvePuzzle1(seeds []int, mappings MappingList) int {
+	var result int = math.MaxInt
+	var position int = 0
 
 	for _, seed := range seeds {
 		position = seed
-		for mappingLevel := 0; mappingLevel < 7; mappingLevel++ {
+		for mappingLevel := 0; mappingLevel < MAPPING_LEVELS; mappingLevel++ {
 			position = applyMapping(position, mappings.mapping[mappingLevel])
 		}
 		if position < result {
@@ -109,74 +131,76 @@ func SolvePuzzle1(seeds []uint64, mappings MappingList) uint64 {
 
 // ---------------------------------------------------------------------------
 
-func getSeeds2(input string) []Seed {
-	var seedsList []Seed
-	numbersList := strings.Fields(input)
+// the seeds line holds pairs of start and length
+func getSeeds2(line utils.Line) (utils.IntervalSet, error) {
+	var seeds utils.IntervalSet
+	numbersList, err := utils.Ints[int](line)
+	if err != nil {
+		return seeds, err
+	}
+	if len(numbersList)%2 != 0 {
+		return seeds, line.Errorf("expected pairs of start and length, got %d numbers", len(numbersList))
+	}
 
 	for i := 0; i < len(numbersList); i += 2 {
-		start := utils.StringToUint64(numbersList[i])
-		length := utils.StringToUint64(numbersList[i+1])
-		seedsList = append(seedsList, Seed{start: start, length: length})
+		interval, ok := utils.IntervalOf(numbersList[i], numbersList[i+1])
+		if !ok {
+			return seeds, line.Errorf("range of %d seeds from %d does not fit into an int", numbersList[i+1], numbersList[i])
+		}
+		seeds = seeds.Add(interval)
 	}
-	fmt.Printf("getSeeds2() - %v\n", seedsList)
-	return seedsList
+	//fmt.Printf("getSeeds2() - %v\n", seeds)
+	return seeds, nil
 }
 
-func SolvePuzzle2(seeds []Seed, mappings MappingList) uint64 {
-	var result uint64 = math.MaxUint64
-	var position uint64 = 0
-
-	for _, seedRange := range seeds {
-		fmt.Printf("> Working on: %v\n", seedRange)
-		for seed := seedRange.start; seed < seedRange.start+seedRange.length; seed++ {
-			position = seed
-			for mappingLevel := 0; mappingLevel < 7; mappingLevel++ {
-				position = applyMapping(position, mappings.mapping[mappingLevel])
-			}
-			if position < result {
-				result = position
-			}
-			//fmt.Printf("SolvePuzzle2() - Seed %v -> Position %v\n", seed, position)
-		}
+// instead of mapping every single seed the ranges are mapped as a whole
+func SolvePuzzle2(seeds utils.IntervalSet, mappings MappingList) int {
+	positions := seeds
+	for mappingLevel := 0; mappingLevel < MAPPING_LEVELS; mappingLevel++ {
+		positions = applyMappingToSet(positions, mappings.mapping[mappingLevel])
+		//fmt.Printf("SolvePuzzle2() - Level %d -> %v\n", mappingLevel, positions)
 	}
-
-	return result
+	if positions.Empty() {
+		return 0
+	}
+	return positions.Min()
 }
 
 // ---------------------------------------------------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(5, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	seeds    []int
+	seeds2   utils.IntervalSet
+	mappings MappingList
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Parse(input []string) error {
+	sections := utils.Sections(input)
+	if len(sections) == 0 || len(sections[0].Lines) != 1 || !strings.HasPrefix(sections[0].Lines[0], "seeds:") {
+		return fmt.Errorf("expected seeds on the first line")
 	}
+	line := sections[0].Line(0)
 
-	var seeds []uint64
-	var seedsString string
-	idx := strings.IndexRune(input[0], ':')
-	seedsString = input[0][idx+1:]
-	seeds = getSeeds(seedsString)
-
-	// consume first 3 lines
-	input = input[3:]
-	mappings := createMappings(input)
+	var err error
+	if s.seeds, err = getSeeds(line); err != nil {
+		return err
+	}
+	if s.seeds2, err = getSeeds2(line); err != nil {
+		return err
+	}
 
-	result1 := SolvePuzzle1(seeds, mappings)
+	s.mappings, err = createMappings(sections[1:])
+	return err
+}
 
-	seeds2 := getSeeds2(seedsString)
-	result2 := SolvePuzzle2(seeds2, mappings)
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.seeds, s.mappings)
+}
 
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t\t", result1)
-	fmt.Println("Result 2:\t\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.seeds2, s.mappings)
 }
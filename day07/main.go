@@ -1,19 +1,13 @@
-package main
+package day07
 
 import (
 	"fmt"
 	"sort"
-	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
 )
 
-// the flag runTest defines which data file to read
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // ---------------------------------------------------------------------------
 
 type Hand struct {
@@ -21,39 +15,53 @@ type Hand struct {
 	Bet   int
 }
 
+const CARDS_PER_HAND int = 5
+
 // MapCardValue maps non-numeric card characters to their corresponding values.
-func MapCardValue(char rune) int {
+func MapCardValue(char rune) (int, error) {
 	switch char {
 	case 'T':
-		return 10
+		return 10, nil
 	case 'J':
-		// TODO: use 11 for part 1
-		return 1
+		// joker for part 2, see withoutJokers() for part 1
+		return 1, nil
 	case 'Q':
-		return 12
+		return 12, nil
 	case 'K':
-		return 13
+		return 13, nil
 	case 'A':
-		return 14
+		return 14, nil
+	case '2', '3', '4', '5', '6', '7', '8', '9':
+		return int(char - '0'), nil
 	default:
-		return utils.StringToInt(string(char))
+		return 0, fmt.Errorf("invalid card %q", char)
 	}
 }
 
-// ParseHand parses a string into a Hand struct.
-func ParseHand(input string) Hand {
-	parts := strings.Fields(input)
+// ParseHand parses a line into a Hand struct.
+func ParseHand(line utils.Line) (Hand, error) {
+	parts := line.Fields()
+	if len(parts) != 2 || len(parts[0].Text) != CARDS_PER_HAND {
+		return Hand{}, line.Errorf("expected %d cards and a bet", CARDS_PER_HAND)
+	}
 
 	var cards []int
-	for _, char := range parts[0] {
-		cards = append(cards, MapCardValue(char))
+	for idx, char := range parts[0].Text {
+		card, err := MapCardValue(char)
+		if err != nil {
+			return Hand{}, parts[0].ErrorfAt(idx, "%v", err)
+		}
+		cards = append(cards, card)
 	}
 
-	bet := utils.StringToInt(parts[1])
+	bet, err := parts[1].Int(0, parts[1].Text)
+	if err != nil {
+		return Hand{}, err
+	}
 	return Hand{
 		Cards: cards,
 		Bet:   bet,
-	}
+	}, nil
 }
 
 func evaluateHand(hand Hand) int {
@@ -125,8 +133,25 @@ func sortByEvaluation(hands []Hand) {
 	})
 }
 
+// part 1 has no jokers, J is a regular card between T and Q
+func withoutJokers(hands []Hand) []Hand {
+	result := make([]Hand, len(hands))
+	for idx, hand := range hands {
+		cards := make([]int, len(hand.Cards))
+		for c, card := range hand.Cards {
+			if card == 1 {
+				card = 11
+			}
+			cards[c] = card
+		}
+		result[idx] = Hand{Cards: cards, Bet: hand.Bet}
+	}
+	return result
+}
+
 func SolvePuzzle1(hands []Hand) int {
 	var result int = 0
+	hands = withoutJokers(hands)
 	sortByEvaluation(hands)
 	for idx, hand := range hands {
 		//fmt.Printf("SolvePuzzle1() - Hand: %v\n", hand)
@@ -149,31 +174,29 @@ func SolvePuzzle2(hands []Hand) int {
 
 // ---------------------------------------------------------------------------
 
-func main() {
-	var input []string
-
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func init() {
+	puzzle.Register(7, func() puzzle.Solver { return &solver{} })
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+type solver struct {
+	hands []Hand
+}
 
-	var hands []Hand
-	for _, line := range input {
-		hands = append(hands, ParseHand(line))
+func (s *solver) Parse(input []string) error {
+	for idx, line := range input {
+		hand, err := ParseHand(utils.NewLine(idx, line))
+		if err != nil {
+			return err
+		}
+		s.hands = append(s.hands, hand)
 	}
+	return nil
+}
 
-	//result1 := SolvePuzzle1(hands)
-	result2 := SolvePuzzle2(hands)
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.hands)
+}
 
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	//fmt.Println("Result 1:\t\t\t", result1)
-	fmt.Println("Result 2:\t\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.hands)
 }
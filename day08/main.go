@@ -1,42 +1,49 @@
-package main
+package day08
 
 import (
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/graph"
+	"github.com/cdr74/AdventOfCode2023/utils/number"
 )
 
-// the flag runTest defines which data file to read test.data or actual.data
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // ---------------------------------------------------------------------------
 
+// Input format: AAA = (BBB, BBB), the destinations keep their position to
+// report unknown ones
 type Transition struct {
-	Position string
-	Left     string
-	Right    string
+	Position string     `parse:"%s = ("`
+	Left     utils.Line `parse:"%s, "`
+	Right    utils.Line `parse:"%s)"`
 }
 
-// Input format: AAA = (BBB, BBB)
-func parseTransition(input string) Transition {
+func parseTransition(line utils.Line) (Transition, error) {
 	var transition Transition
+	err := utils.ScanStruct(line, &transition)
+	return transition, err
+}
 
-	// Extract the position
-	positionEnd := strings.Index(input, " =")
-	transition.Position = input[:positionEnd]
-
-	// Extract the left and right elements
-	leftStart := strings.Index(input, "(") + 1
-	rightEnd := strings.Index(input, ")")
-	elements := strings.Split(input[leftStart:rightEnd], ", ")
-	transition.Left = elements[0]
-	transition.Right = elements[1]
+// the network has an edge labelled L and one labelled R from each position
+func createNetwork(transitions []Transition) *graph.Graph[string] {
+	network := graph.NewDirected[string]()
+	for _, transition := range transitions {
+		network.AddLabeledEdge(transition.Position, transition.Left.Text, "L")
+		network.AddLabeledEdge(transition.Position, transition.Right.Text, "R")
+	}
+	return network
+}
 
-	return transition
+// follows the instructions from position till isEnd is true, returns the steps
+func countSteps(instructions string, network *graph.Graph[string], position string, isEnd func(string) bool) int {
+	steps := 0
+	for !isEnd(position) {
+		position, _ = network.Next(position, string(instructions[steps%len(instructions)]))
+		steps++
+	}
+	return steps
 }
 
 // ---------------------------------------------------------------------------
@@ -45,129 +52,103 @@ func parseTransition(input string) Transition {
 // transitions are positions with a next point to reach based on an instruction
 // instructions are followed till a transition leads to ZZZ, if end of instructions is reached
 // start them from the beginning
-func SolvePuzzle1(instructions string, transitions map[string]Transition) int {
-	var result int = 0
-	instructionList := []rune(instructions)
-	idx := 0
-
-	currentPosition := "AAA"
-	for currentPosition != "ZZZ" {
-		transition := transitions[currentPosition]
-		if instructionList[idx] == 'L' {
-			currentPosition = transition.Left
-		} else {
-			currentPosition = transition.Right
-		}
-		idx++
-		if idx == len(instructionList) {
-			idx = 0
-		}
-		result++
+func SolvePuzzle1(instructions string, network *graph.Graph[string]) int {
+	// test data of part 2 has no AAA, we would loop forever
+	if !network.HasNode("AAA") {
+		return 0
 	}
 
-	return result
+	return countSteps(instructions, network, "AAA", func(position string) bool { return position == "ZZZ" })
 }
 
 // ---------------------------------------------------------------------------
 
-// gcd calculates the greatest common divisor using Euclid's algorithm
-func gcd(a, b uint64) uint64 {
-	for b != 0 {
-		a, b = b, a%b
-	}
-	return a
-}
-
-// lcm calculates the least common multiple of two integers
-func lcm(a, b uint64) uint64 {
-	return a / gcd(a, b) * b
-}
-
-// lcmArray calculates the least common multiple of an array of integers
-func lcmArray(numbers []uint64) uint64 {
-	result := numbers[0]
-	for i := 1; i < len(numbers); i++ {
-		result = lcm(result, numbers[i])
-	}
-	return result
-}
-
 // more complex version of SolvePuzzle1
 // - we have multiple start points, namely each Position that ends in A, eg ZBA
 // - we follow the instructions simultanously for each start point
 // - we stop when all start points reach a position that ends in Z, eg BQZ
 // instructions are a sequence of L, R indicating whether a transition leads to left or right next
 // transitions are positions with a next point to reach based on an instruction
-func SolvePuzzle2(instructions string, transitions map[string]Transition) uint64 {
-	var result uint64 = 1
-	instructionList := []rune(instructions)
-
-	// initialize the start positions
-	var positionList []string
-	for position := range transitions {
-		if position[len(position)-1] == 'A' {
-			positionList = append(positionList, position)
-		}
+func SolvePuzzle2(instructions string, network *graph.Graph[string]) any {
+	endsWith := func(char byte) func(string) bool {
+		return func(position string) bool { return position[len(position)-1] == char }
 	}
 
-	lengthOfPath := make([]uint64, len(positionList))
-	for x, position := range positionList {
-		idx := 0
-		length := uint64(0)
-		currentPosition := position
-		for currentPosition[len(currentPosition)-1] != 'Z' {
-			transition := transitions[currentPosition]
-			if instructionList[idx] == 'L' {
-				currentPosition = transition.Left
-			} else {
-				currentPosition = transition.Right
-			}
-			idx++
-			if idx == len(instructionList) {
-				idx = 0
-			}
-			length++
+	isStart, isEnd := endsWith('A'), endsWith('Z')
+
+	// each start reaches its end in a loop, all are at an end at the lcm
+	var lengthOfPath []uint64
+	for _, position := range network.Nodes() {
+		if isStart(position) {
+			lengthOfPath = append(lengthOfPath, uint64(countSteps(instructions, network, position, isEnd)))
 		}
-		lengthOfPath[x] = length
 	}
 
-	result = lcmArray(lengthOfPath)
-
-	return result
+	steps, err := number.LcmOf(lengthOfPath)
+	if err != nil {
+		// too many steps for uint64, the exact number is still the answer
+		return number.BigLcmOf(lengthOfPath)
+	}
+	return steps
 }
 
 // ---------------------------------------------------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(8, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	instructions string
+	network      *graph.Graph[string]
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Parse(input []string) error {
+	if len(input) < 2 {
+		return fmt.Errorf("expected instructions and transitions")
+	}
+	s.instructions = input[0]
+	if s.instructions == "" {
+		return utils.NewLine(0, input[0]).Errorf("no instructions")
+	}
+	if idx := strings.IndexFunc(s.instructions, func(r rune) bool { return r != 'L' && r != 'R' }); idx >= 0 {
+		return utils.NewLine(0, input[0]).ErrorfAt(idx, "instruction must be L or R")
 	}
 
-	instructions := input[0]
-	input = input[2:]
+	if strings.TrimSpace(input[1]) != "" {
+		return utils.NewLine(1, input[1]).Errorf("expected a blank line after the instructions")
+	}
 
-	transitions := make(map[string]Transition)
-	for line := range input {
-		transition := parseTransition(input[line])
-		transitions[transition.Position] = transition
+	var transitions []Transition
+	positions := make(map[string]bool)
+	for idx, line := range input[2:] {
+		context := utils.NewLine(idx+2, line)
+		transition, err := parseTransition(context)
+		if err != nil {
+			return err
+		}
+		if positions[transition.Position] {
+			return context.Errorf("position %s is defined twice", transition.Position)
+		}
+		positions[transition.Position] = true
+		transitions = append(transitions, transition)
+	}
+	// following a destination without a line of its own never ends
+	for _, transition := range transitions {
+		for _, destination := range []utils.Line{transition.Left, transition.Right} {
+			if !positions[destination.Text] {
+				return destination.ErrorfAt(0, "unknown position %s", destination.Text)
+			}
+		}
 	}
+	s.network = createNetwork(transitions)
+	return nil
+}
+
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.instructions, s.network)
+}
 
-	// new test data invalidates puzzle 1
-	//result1 := SolvePuzzle1(instructions, transitions)
-	result2 := SolvePuzzle2(instructions, transitions)
-	stopwatch.Stop()
-
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	//fmt.Println("Result 1:\t\t\t", result1)
-	fmt.Println("Result 2:\t\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.instructions, s.network)
 }
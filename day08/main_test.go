@@ -0,0 +1,28 @@
+package day08
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []string
+		line   int
+		column int
+	}{
+		{"unknown destination", []string{"LR", "", "AAA = (BBB, ZZZ)", "BBB = (AAA, ZZY)", "ZZZ = (ZZZ, ZZZ)"}, 4, 13},
+		{"second line not blank", []string{"LR", "AAA = (AAA, AAA)", "", "AAA = (AAA, AAA)"}, 2, 0},
+		{"position twice", []string{"L", "", "AAA = (ZZZ, ZZZ)", "ZZZ = (ZZZ, ZZZ)", "AAA = (AAA, AAA)"}, 5, 0},
+	}
+	for _, test := range tests {
+		var parseErr *utils.ParseError
+		err := (&solver{}).Parse(test.input)
+		if !errors.As(err, &parseErr) || parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("%s: Expected an error at line %d, column %d, but got %v", test.name, test.line, test.column, err)
+		}
+	}
+}
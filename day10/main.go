@@ -17,182 +17,137 @@
 //
 // ----------------------------------------------------------------------------
 
-package main
+package day10
 
 import (
 	"fmt"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Section ---------------------------------
 
-type Position struct {
-	Row int
-	Col int
+// the two directions each pipe connects
+var pipes = map[byte][2]grid.Point{
+	'|': {grid.North, grid.South},
+	'-': {grid.East, grid.West},
+	'J': {grid.North, grid.West},
+	'L': {grid.North, grid.East},
+	'7': {grid.South, grid.West},
+	'F': {grid.South, grid.East},
 }
 
-var Start Position
-var pipeline []Position
-
-func inputToArray(input []string) [][]int {
-	width := len(input[0]) + 2
-	depth := len(input) + 2
-	dataArr := utils.CreateIntArray(width, depth, '.')
+func inputToMaze(input []string) (*grid.Grid[byte], grid.Point, error) {
+	maze, err := grid.ParseBytes(input)
+	if err != nil {
+		return nil, grid.Point{}, err
+	}
 
-	for r, row := range input {
-		for c, char := range row {
-			dataArr[r+1][c+1] = int(char)
-			if char == 'S' {
-				Start = Position{Row: r + 1, Col: c + 1}
-			}
-		}
+	isStart := func(char byte) bool { return char == 'S' }
+	starts := maze.FindAll(isStart)
+	switch {
+	case len(starts) == 0:
+		return nil, grid.Point{}, fmt.Errorf("no start S found")
+	case len(starts) > 1:
+		second := starts[1]
+		return nil, grid.Point{}, utils.NewLine(second.Row, input[second.Row]).ErrorfAt(second.Col, "second start S")
 	}
 
-	return dataArr
+	return maze, starts[0], nil
 }
 
-func printMaze(maze [][]int) {
-	fmt.Println("")
-	for _, row := range maze {
-		for _, char := range row {
-			if char == 0 || char == 1 {
-				fmt.Printf("%d", char)
-			} else {
-				switch char {
-				case 'J':
-					fmt.Print("┘")
-				case 'L':
-					fmt.Print("└")
-				case '7':
-					fmt.Print("┐")
-				case 'F':
-					fmt.Print("┌")
-				case 'S':
-					fmt.Print("S")
-				default:
-					fmt.Print(string(char))
-				}
-			}
+// -------------------------- Puzzle part 1 ----------------------------------
+
+// true if the pipe at pos has an end at from
+func connectsTo(maze *grid.Grid[byte], pos grid.Point, from grid.Point) bool {
+	char, ok := maze.Get(pos)
+	if !ok {
+		return false
+	}
+	for _, dir := range pipes[char] {
+		if pos.Add(dir) == from {
+			return true
 		}
-		fmt.Println("")
 	}
+	return false
 }
 
-// -------------------------- Puzzle part 1 ----------------------------------
+// the pipe at currentPos connects to prevPos, returns its other end
+func nextPosition(maze *grid.Grid[byte], currentPos grid.Point, prevPos grid.Point) grid.Point {
+	//fmt.Printf("nextPosition() - current pos: %v, sign: %c\n", currentPos, maze.At(currentPos))
 
-// not checking for dead ends!
-func nextPosition(maze [][]int, currentPos Position, prevPos Position) Position {
-	var nextPos Position
-	//fmt.Printf("nextPosition() - current pos: %v, sign: %v\n", currentPos, string(maze[currentPos.Row][currentPos.Col]))
+	ends := pipes[maze.At(currentPos)]
+	if currentPos.Add(ends[0]) == prevPos {
+		return currentPos.Add(ends[1])
+	}
+	return currentPos.Add(ends[0])
+}
 
-	switch maze[currentPos.Row][currentPos.Col] {
-	case '|':
-		if prevPos.Row < currentPos.Row {
-			nextPos = Position{Row: currentPos.Row + 1, Col: currentPos.Col}
-		} else {
-			nextPos = Position{Row: currentPos.Row - 1, Col: currentPos.Col}
-		}
-	case '-':
-		if prevPos.Col < currentPos.Col {
-			nextPos = Position{Row: currentPos.Row, Col: currentPos.Col + 1}
-		} else {
-			nextPos = Position{Row: currentPos.Row, Col: currentPos.Col - 1}
-		}
-	case 'J':
-		if prevPos.Col == currentPos.Col {
-			nextPos = Position{Row: currentPos.Row, Col: currentPos.Col - 1}
-		} else {
-			nextPos = Position{Row: currentPos.Row - 1, Col: currentPos.Col}
-		}
-	case 'L':
-		if prevPos.Col == currentPos.Col {
-			nextPos = Position{Row: currentPos.Row, Col: currentPos.Col + 1}
-		} else {
-			nextPos = Position{Row: currentPos.Row - 1, Col: currentPos.Col}
+// finds a pipe next to start (S) that connects back to it, in order of grid.Directions4
+func findFirstPipe(maze *grid.Grid[byte], start grid.Point) (grid.Point, error) {
+	for _, dir := range grid.Directions4 {
+		neighbor := start.Add(dir)
+		if connectsTo(maze, neighbor, start) {
+			return neighbor, nil
 		}
-	case '7':
-		if prevPos.Col == currentPos.Col {
-			nextPos = Position{Row: currentPos.Row, Col: currentPos.Col - 1}
-		} else {
-			nextPos = Position{Row: currentPos.Row + 1, Col: currentPos.Col}
-		}
-	case 'F':
-		if prevPos.Col == currentPos.Col {
-			nextPos = Position{Row: currentPos.Row, Col: currentPos.Col + 1}
-		} else {
-			nextPos = Position{Row: currentPos.Row + 1, Col: currentPos.Col}
-		}
-	default:
-		panicMsg := fmt.Sprintf("No connection found from: %v\n", currentPos)
-		panic(panicMsg)
 	}
-
-	return nextPos
+	return grid.Point{}, fmt.Errorf("no pipe connected to S at %v", start)
 }
 
-// Returns count of steps in the pipe connected to Start (S) furthest away from it
-func SolvePuzzle1(maze [][]int) int {
-	var currentPos Position
-
-	// skipping to find by algo, hardcoded start
-	if runTest {
-		currentPos = Position{Row: 1, Col: 4}
-		//currentPos = Position{Row: 3, Col: 2}
-	} else {
-		currentPos = Position{Row: 31, Col: 121}
+// Returns all positions of the pipe connected to start (S) in order, firstPipe
+// is the pipe next to S to follow. It is an error if the pipe ends before it
+// gets back to S.
+func findPipeline(maze *grid.Grid[byte], start grid.Point, firstPipe grid.Point) ([]grid.Point, error) {
+	if !connectsTo(maze, firstPipe, start) {
+		return nil, fmt.Errorf("%v is not a pipe connected to S at %v", firstPipe, start)
 	}
+	pipeline := []grid.Point{start, firstPipe}
 
-	prevPos := Position{Col: Start.Col, Row: Start.Row}
+	prevPos := start
+	currentPos := firstPipe
 	nextPos := nextPosition(maze, currentPos, prevPos)
-	pipeline = append(pipeline, Start)
-	pipeline = append(pipeline, currentPos)
-	for nextPos != Start {
-		fmt.Printf("Current pos: %v\n", currentPos)
+	for nextPos != start {
+		//fmt.Printf("Current pos: %v\n", currentPos)
+		if !connectsTo(maze, nextPos, currentPos) {
+			return nil, fmt.Errorf("pipe at %v leads to %v which does not connect back", currentPos, nextPos)
+		}
 		pipeline = append(pipeline, nextPos)
 		prevPos = currentPos
 		currentPos = nextPos
 		nextPos = nextPosition(maze, currentPos, prevPos)
 	}
 
+	return pipeline, nil
+}
+
+// Returns count of steps in the pipeline furthest away from start
+func SolvePuzzle1(pipeline []grid.Point) int {
 	return (len(pipeline)) / 2
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-func replacePipelineElements(maze [][]int) {
+// marks the pipeline elements, all others remain '.'
+func markPipeline(maze *grid.Grid[byte], pipeline []grid.Point) *grid.Grid[byte] {
+	marks := grid.New(maze.Rows(), maze.Cols(), byte('.'))
 	for _, position := range pipeline {
-		char := maze[position.Row][position.Col]
-		switch char {
+		switch maze.At(position) {
 		case 'J', 'L', '|':
-			maze[position.Row][position.Col] = 1
+			marks.Set(position, '1')
 		case '-', '7', 'F', 'S':
 			// test and actual input use same direction S
-			maze[position.Row][position.Col] = 0
+			marks.Set(position, '0')
 		}
 	}
+	return marks
 }
 
-func replaceRemainingElements(maze [][]int) {
-	for r, row := range maze {
-		for c, _ := range row {
-			if maze[r][c] != 0 && maze[r][c] != 1 {
-				maze[r][c] = '.'
-			}
-		}
-	}
-}
-
-func calcCount(maze [][]int, r int, c int) int {
+func calcCount(row []byte, c int) int {
 	var count int
-	for i := c; i < len(maze[r]); i++ {
-		if maze[r][i] == 1 {
+	for i := c; i < len(row); i++ {
+		if row[i] == '1' {
 			count++
 		}
 	}
@@ -201,26 +156,24 @@ func calcCount(maze [][]int, r int, c int) int {
 
 // Calculate fields inside the pipeline, we apply a simple algorithm
 //  0. Manually replace S with F in data file
-//  1. Replace all pipeline lements as follows (count to right north facing):
+//  1. Mark all pipeline lements as follows (count to right north facing):
 //     -, F, 7 = 0
 //     |, J, L = 1
 //
-// 2. Repalce all elements in Maze that are not 0 or 1 with '.'
+// 2. All other elements are '.'
 // 3. Count for each dot whether the summ of 0, 1 elements are even or odd
 // 4. If even the "." is outside
 // 5. If odd the "." is inside and we increment area counter
 // 6. Return area counter
-func SolvePuzzle2(maze [][]int) int {
+func SolvePuzzle2(maze *grid.Grid[byte], pipeline []grid.Point) int {
 	var result int = 0
-	printMaze(maze)
-	replacePipelineElements(maze)
-	printMaze(maze)
-	replaceRemainingElements(maze)
-	printMaze(maze)
-	for r, row := range maze {
-		for c, _ := range row {
-			if maze[r][c] == '.' {
-				count := calcCount(maze, r, c+1)
+	marks := markPipeline(maze, pipeline)
+	//fmt.Print(marks)
+	for r := 0; r < marks.Rows(); r++ {
+		row := marks.Row(r)
+		for c, char := range row {
+			if char == '.' {
+				count := calcCount(row, c+1)
 				if count%2 == 1 {
 					result++
 				}
@@ -230,30 +183,63 @@ func SolvePuzzle2(maze [][]int) int {
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
+
+func init() {
+	puzzle.Register(10, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	maze      *grid.Grid[byte]
+	start     grid.Point
+	firstPipe *grid.Point
+	pipeline  []grid.Point
+}
 
-func main() {
-	var input []string
+func (s *solver) Settings() []puzzle.Setting {
+	return []puzzle.Setting{
+		{Name: "first_pipe", Usage: "row,col of the pipe next to S to follow, auto finds one", Test: "auto", Actual: "auto"},
+	}
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func (s *solver) Configure(settings puzzle.Settings) error {
+	value := settings["first_pipe"]
+	if value == "auto" {
+		s.firstPipe = nil
+		return nil
+	}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+	var pos grid.Point
+	if _, err := fmt.Sscanf(value, "%d,%d", &pos.Row, &pos.Col); err != nil {
+		return fmt.Errorf("setting first_pipe %q: %w", value, err)
 	}
+	s.firstPipe = &pos
+	return nil
+}
 
-	maze := inputToArray(input)
+// both parts need the pipeline, it is searched once
+func (s *solver) Parse(input []string) error {
+	var err error
+	if s.maze, s.start, err = inputToMaze(input); err != nil {
+		return err
+	}
 
-	result1 := SolvePuzzle1(maze)
-	result2 := SolvePuzzle2(maze)
-	stopwatch.Stop()
+	firstPipe := s.firstPipe
+	if firstPipe == nil {
+		found, err := findFirstPipe(s.maze, s.start)
+		if err != nil {
+			return err
+		}
+		firstPipe = &found
+	}
+	s.pipeline, err = findPipeline(s.maze, s.start, *firstPipe)
+	return err
+}
+
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.pipeline)
+}
 
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.maze, s.pipeline)
 }
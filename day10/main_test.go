@@ -0,0 +1,48 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+)
+
+// S has to be part of a closed pipe, otherwise there is nothing to solve
+func TestParseBrokenPipeline(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+	}{
+		{"no pipe at S", []string{".....", ".S-..", "....."}},
+		{"dead end", []string{".....", ".S-7.", ".|...", ".L-J."}},
+		{"leaves the grid", []string{"S-7", "|.|", "L--"}},
+	}
+
+	for _, test := range tests {
+		if err := (&solver{}).Parse(test.input); err == nil {
+			t.Errorf("%s: Expected an error", test.name)
+		}
+	}
+}
+
+func TestConfiguredFirstPipe(t *testing.T) {
+	input := []string{".....", ".S-7.", ".|.|.", ".L-J."}
+
+	s := &solver{}
+	if err := s.Configure(puzzle.Settings{"first_pipe": "2,1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse(input); err != nil {
+		t.Fatal(err)
+	}
+	if result := s.Part1(); result != 4 {
+		t.Errorf("Expected 4, but got %v", result)
+	}
+
+	s = &solver{}
+	if err := s.Configure(puzzle.Settings{"first_pipe": "2,2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse(input); err == nil {
+		t.Errorf("Expected an error for a first pipe that does not connect to S")
+	}
+}
@@ -9,92 +9,46 @@
 //           by 1000000 empty cols/rows making a representation as matrix imposssible
 // ---------------------------------------------------------------------------
 
-package main
+package day11
 
 import (
-	"fmt"
-	"time"
-
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Section ---------------------------------
 
-type Position struct {
-	row int
-	col int
-}
-
-func inputLineToValues(input []string) [][]int {
-	var result [][]int
-
-	for _, line := range input {
-		var row []int
-		for _, char := range line {
-			if char == '.' {
-				row = append(row, 0)
-			} else {
-				row = append(row, 1)
-			}
-		}
-		result = append(result, row)
-	}
-	return result
+func inputToStarMap(input []string) (*grid.Grid[byte], error) {
+	return grid.ParseOf(input, ".#")
 }
 
-func isColumnEmpty(starMap [][]int, idx int) bool {
-	for _, row := range starMap {
-		if row[idx] == 1 {
-			return false
-		}
-	}
-	return true
+func isStar(char byte) bool {
+	return char == '#'
 }
 
-func isRowEmpty(row []int) bool {
-	for _, value := range row {
-		if value == 1 {
+func isRowEmpty(row []byte) bool {
+	for _, char := range row {
+		if isStar(char) {
 			return false
 		}
 	}
 	return true
 }
 
-func getStarList(starMap [][]int) []Position {
-	var result []Position
-	for r := 0; r < len(starMap); r++ {
-		for c := 0; c < len(starMap[r]); c++ {
-			if starMap[r][c] == 1 {
-				result = append(result, Position{row: r, col: c})
-			}
-		}
-	}
-	return result
-}
-
-func getListofEmptyRows(starMap [][]int) []int {
+func getListofEmptyRows(starMap *grid.Grid[byte]) []int {
 	var result []int
-	for r := 0; r < len(starMap); r++ {
-		if isRowEmpty(starMap[r]) {
+	for r := 0; r < starMap.Rows(); r++ {
+		if isRowEmpty(starMap.Row(r)) {
 			result = append(result, r)
 		}
 	}
 	return result
 }
 
-func getListofEmptyColumns(starMap [][]int) []int {
-	var result []int
-	for c := 0; c < len(starMap[0]); c++ {
-		if isColumnEmpty(starMap, c) {
-			result = append(result, c)
-		}
-	}
-	return result
+// the columns of the star map are the rows of its transposition
+func getListofEmptyColumns(starMap *grid.Grid[byte]) []int {
+	return getListofEmptyRows(starMap.Transpose())
 }
 
 // returns how many elements are in a list between start and end
@@ -108,9 +62,9 @@ func countOfValuesBetween(start int, end int, values []int) int {
 	return result
 }
 
-func getDistanceBetweenStars(star1 Position, star2 Position, emptyRows []int, emptyCols []int, expansionFactor int) int {
-	xFrom, xTo := utils.Min(star1.col, star2.col), utils.Max(star1.col, star2.col)
-	yFrom, yTo := utils.Min(star1.row, star2.row), utils.Max(star1.row, star2.row)
+func getDistanceBetweenStars(star1 grid.Point, star2 grid.Point, emptyRows []int, emptyCols []int, expansionFactor int) int {
+	xFrom, xTo := utils.Min(star1.Col, star2.Col), utils.Max(star1.Col, star2.Col)
+	yFrom, yTo := utils.Min(star1.Row, star2.Row), utils.Max(star1.Row, star2.Row)
 
 	xDist := utils.Abs(xTo-xFrom) - countOfValuesBetween(xFrom, xTo, emptyCols) + countOfValuesBetween(xFrom, xTo, emptyCols)*expansionFactor
 	yDist := utils.Abs(yTo-yFrom) - countOfValuesBetween(yFrom, yTo, emptyRows) + countOfValuesBetween(yFrom, yTo, emptyRows)*expansionFactor
@@ -118,12 +72,12 @@ func getDistanceBetweenStars(star1 Position, star2 Position, emptyRows []int, em
 	return xDist + yDist
 }
 
-func getDistanceBetweenAllStars(starMap [][]int, expansionFactor int) int {
+func getDistanceBetweenAllStars(starMap *grid.Grid[byte], expansionFactor int) int {
 	var result int = 0
 
 	emptyRows := getListofEmptyRows(starMap)
 	emptyCols := getListofEmptyColumns(starMap)
-	starList := getStarList(starMap)
+	starList := starMap.FindAll(isStar)
 
 	for i := 0; i < len(starList); i++ {
 		for j := i + 1; j < len(starList); j++ {
@@ -135,42 +89,36 @@ func getDistanceBetweenAllStars(starMap [][]int, expansionFactor int) int {
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-func SolvePuzzle1(starMap [][]int) int {
+func SolvePuzzle1(starMap *grid.Grid[byte]) int {
 	return getDistanceBetweenAllStars(starMap, 2)
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-func SolvePuzzle2(starMap [][]int) int {
+func SolvePuzzle2(starMap *grid.Grid[byte]) int {
 	return getDistanceBetweenAllStars(starMap, 1000000)
 }
 
-// -------------------------- Main entry -------------------------------------
-
-func main() {
-	var input []string
+// -------------------------- Solver entry -----------------------------------
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
-
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func init() {
+	puzzle.Register(11, func() puzzle.Solver { return &solver{} })
+}
 
-	values := inputLineToValues(input)
-	result1 := SolvePuzzle1(values)
+type solver struct {
+	starMap *grid.Grid[byte]
+}
 
-	values = inputLineToValues(input)
-	result2 := SolvePuzzle2(values)
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.starMap, err = inputToStarMap(input)
+	return err
+}
 
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePuzzle1(s.starMap)
+}
 
-	// -------------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePuzzle2(s.starMap)
 }
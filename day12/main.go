@@ -18,120 +18,113 @@
 //	https://pastebin.com/djb8RJ85
 //
 // ---------------------------------------------------------------------------
-package main
+package day12
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-func getSequenceList(line string) []int {
-	var result []int
-	numberList := line[strings.Index(line, " "):]
-	numbers := strings.Split(numberList, ",")
-	for _, number := range numbers {
-		result = append(result, utils.StringToInt(number))
-	}
-	return result
+// Record is one input line, springs like "?###????????" and the group lengths
+type Record struct {
+	springs      string
+	sequenceList []int
 }
 
-var memoizedResults map[string]int = make(map[string]int)
+var recordFormat = utils.MustLineFormat("%s %s")
 
-func containsValue(list []byte, value byte) bool {
-	for i := 0; i < len(list); i++ {
-		if list[i] == value {
-			return true
+func parseRecord(line utils.Line) (Record, error) {
+	var record Record
+	if err := recordFormat.Scan(line, &record.springs, &record.sequenceList); err != nil {
+		return record, err
+	}
+	if idx := strings.IndexFunc(record.springs, func(r rune) bool { return !strings.ContainsRune(".#?", r) }); idx >= 0 {
+		return record, line.ErrorfAt(idx, "expected . # or ?")
+	}
+	for _, length := range record.sequenceList {
+		if length < 1 {
+			return record, line.Errorf("group length must be positive, got %d", length)
 		}
 	}
-	return false
+	return record, nil
 }
 
-func memoizedRecursiveCount(line []byte, sequenceList []int) int {
-	//fmt.Printf("memoizedRecursiveCount() - line: %s, sequence: %v\n", string(line), sequenceList)
-	memoKey := fmt.Sprintf("%s-%v", string(line), sequenceList)
-	if val, ok := memoizedResults[memoKey]; ok {
-		return val
-	}
+// countKey is the state of the recursion, the position in the springs and
+// the index of the next group to place
+type countKey struct {
+	pos   int
+	group int
+}
 
-	count := recursiveCount(line, sequenceList)
-	memoizedResults[memoKey] = count
+// arrangements counts the ways to place the groups into the springs, the
+// counts of each state are memoized
+type arrangements struct {
+	springs      []byte
+	sequenceList []int
+	memo         *utils.Memo[countKey, int]
+}
 
+func memoizedRecursiveCount(line []byte, sequenceList []int) int {
+	a := arrangements{springs: line, sequenceList: sequenceList, memo: utils.NewMemo[countKey, int]()}
+	count := a.count(0, 0)
+	//fmt.Printf("memoizedRecursiveCount() - line: %s, sequence: %v, stats: %+v\n", string(line), sequenceList, a.memo.Stats())
 	return count
 }
 
-// consumes line from start and removes processed characters, calls memoizedRecursiveCount to cahce results
-func recursiveCount(line []byte, sequenceList []int) int {
-	for {
-		if len(sequenceList) == 0 {
-			if containsValue(line, '#') {
-				// invalid combination
-				return 0
-			} else {
-				// no more groups to match, valid combination
-				return 1
-			}
-		}
+func (a *arrangements) count(pos int, group int) int {
+	return a.memo.Do(countKey{pos: pos, group: group}, func() int {
+		return a.recursiveCount(pos, group)
+	})
+}
 
-		// sequenceList is not empty, but line is fully processed
-		if len(line) == 0 {
+// consumes the springs from pos, a '?' is tried as both '.' and '#'
+func (a *arrangements) recursiveCount(pos int, group int) int {
+	if group == len(a.sequenceList) {
+		if bytes.IndexByte(a.springs[pos:], '#') >= 0 {
+			// invalid combination
 			return 0
 		}
+		// no more groups to match, valid combination
+		return 1
+	}
 
-		// remove leading '.' and restart loop
-		if line[0] == '.' {
-			line = []byte(strings.TrimLeft(string(line), "."))
-			continue
-		}
-
-		if line[0] == '?' {
-			// try both options and add them up
-			line[0] = '.'
-			cnt := memoizedRecursiveCount(line, sequenceList)
-			line[0] = '#'
-			cnt += memoizedRecursiveCount(line, sequenceList)
-			return cnt
-		}
+	// groups are left, but line is fully processed
+	if pos >= len(a.springs) {
+		return 0
+	}
 
-		if line[0] == '#' {
-			// start of a group, consume it
-
-			// group must not be followed by a '#' and we need enough chars left in line
-			if len(line) < sequenceList[0]+1 || line[sequenceList[0]] == '#' {
-				return 0
-			}
-
-			// check if for length of group if a '.' exists
-			for j := 0; j < sequenceList[0]; j++ {
-				if line[j] == '.' {
-					// premature end of group, invalid
-					return 0
-				}
-			}
-
-			if len(sequenceList) > 1 {
-				// skip to after group and after the dot following the group
-				line = line[sequenceList[0]+1:]
-				sequenceList = sequenceList[1:]
-				continue
-			}
-
-			line = line[sequenceList[0]:]
-			sequenceList = sequenceList[1:]
-			continue
-		}
+	switch a.springs[pos] {
+	case '.':
+		return a.count(pos+1, group)
+	case '#':
+		return a.placeGroup(pos, group)
+	case '?':
+		// try both options and add them up
+		return a.count(pos+1, group) + a.placeGroup(pos, group)
+	}
+	panic("recursiveCount() - bad input line")
+}
 
-		panic("recursiveCount() - bad input line")
+// starts the group at pos, it must not contain a '.' and not be followed by a '#'
+func (a *arrangements) placeGroup(pos int, group int) int {
+	end := pos + a.sequenceList[group]
+	if end > len(a.springs) || bytes.IndexByte(a.springs[pos:end], '.') >= 0 {
+		return 0
+	}
+	if end == len(a.springs) {
+		return a.count(end, group+1)
+	}
+	if a.springs[end] == '#' {
+		return 0
 	}
+	// skip to after group and after the dot following the group
+	return a.count(end+1, group+1)
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
@@ -139,17 +132,15 @@ func recursiveCount(line []byte, sequenceList []int) int {
 // find comninations of # sequences for the sequenceList (ints with sequence lengths)
 // input "?###???????? 3,2,1" has 10 possible arrangements
 // before and after each group of # there must be a '.' or end of string
-func SolvePart1(input []string) int {
+func SolvePart1(records []Record) int {
 	var result int = 0
 
-	for _, line := range input {
-		sequenceList := getSequenceList(line)
-		sequence := line[:strings.Index(line, " ")] + string('.')
+	for _, record := range records {
+		sequenceList := record.sequenceList
+		sequence := record.springs + string('.')
 
-		// clear cache
-		memoizedResults = make(map[string]int)
 		cnt := memoizedRecursiveCount([]byte(sequence), sequenceList)
-		fmt.Printf("line: %s, sequence: %v, results: %d\n", sequence, sequenceList, cnt)
+		//fmt.Printf("line: %s, sequence: %v, results: %d\n", sequence, sequenceList, cnt)
 		result += cnt
 	}
 	return result
@@ -178,18 +169,13 @@ func multiplySequence(sequence string, factor int) string {
 }
 
 // multiply all by 5
-func SolvePart2(input []string) int {
+func SolvePart2(records []Record) int {
 	var result int = 0
 
-	for _, line := range input {
-		sequenceList := getSequenceList(line)
-		sequenceList = multiplyList(sequenceList, 5)
-
-		sequence := line[:strings.Index(line, " ")]
-		sequence = multiplySequence(sequence, 5)
+	for _, record := range records {
+		sequenceList := multiplyList(record.sequenceList, 5)
+		sequence := multiplySequence(record.springs, 5)
 
-		// clear cache
-		memoizedResults = make(map[string]int)
 		cnt := memoizedRecursiveCount([]byte(sequence), sequenceList)
 		//fmt.Printf("line: %s, sequence: %v, results: %d\n", sequence, sequenceList, cnt)
 		result += cnt
@@ -197,28 +183,31 @@ func SolvePart2(input []string) int {
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(12, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	records []Record
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Parse(input []string) error {
+	for idx, line := range input {
+		record, err := parseRecord(utils.NewLine(idx, line))
+		if err != nil {
+			return err
+		}
+		s.records = append(s.records, record)
 	}
+	return nil
+}
 
-	result1 := SolvePart1(input)
-	result2 := SolvePart2(input)
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePart1(s.records)
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.records)
 }
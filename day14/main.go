@@ -19,216 +19,102 @@
 //
 // Part 2:
 // ---------------------------------------------------------------------------
-package main
+package day14
 
 import (
-	"fmt"
-	"hash/fnv"
-	"time"
-
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-const WALL = 99
-const EMPTY = 0
-const BALL = 1
-
-var ROWS int
-var COLS int
-
-var cache = make(map[uint32]int)
-var field [][]byte
-
-func inputLineToValues(input []string) {
-	ROWS = len(input)
-	COLS = len(input[0])
-	result := make([][]byte, ROWS)
-	for i := range result {
-		result[i] = make([]byte, COLS)
-	}
-
-	for r, line := range input {
-		for c, char := range line {
-			switch char {
-			case 'O':
-				result[r][c] = BALL
-			case '#':
-				result[r][c] = WALL
-			case '.':
-				result[r][c] = EMPTY
-			}
-		}
-	}
-	field = result
-}
+const WALL = '#'
+const EMPTY = '.'
+const BALL = 'O'
 
-func HashField() uint32 {
-	hash := fnv.New32()
-	hash.Write([]byte(fmt.Sprintf("%v", field)))
-	return hash.Sum32()
+func inputToField(input []string) (*grid.Grid[byte], error) {
+	return grid.ParseOf(input, "O#.")
 }
 
-func printField() {
-	for r := 0; r < ROWS; r++ {
-		for c := 0; c < COLS; c++ {
-			switch field[r][c] {
-			case WALL:
-				fmt.Print("#")
-			case EMPTY:
-				fmt.Print(".")
-			case BALL:
-				fmt.Print("O")
-			}
-		}
-		fmt.Printf("\n")
-	}
-	fmt.Printf("\n\n")
-}
-
-func tiltNorth() {
+// lets each ball roll in dir as far as possible
+func tilt(field *grid.Grid[byte], dir grid.Point) {
 	moveCount := 99
 	for moveCount > 0 {
 		moveCount = 0
-		for r := 1; r < ROWS; r++ {
-			for c := 0; c < COLS; c++ {
-				if field[r][c] == BALL && field[r-1][c] == EMPTY {
-					field[r][c] = EMPTY
-					field[r-1][c] = BALL
-					moveCount++
-				}
+		field.Each(func(p grid.Point, value byte) {
+			next := p.Add(dir)
+			if value == BALL && field.InBounds(next) && field.At(next) == EMPTY {
+				field.Set(p, EMPTY)
+				field.Set(next, BALL)
+				moveCount++
 			}
-		}
+		})
 	}
 }
 
-func tiltSouth() {
-	moveCount := 99
-	for moveCount > 0 {
-		moveCount = 0
-		for r := ROWS - 2; r >= 0; r-- {
-			for c := 0; c < COLS; c++ {
-				if field[r][c] == BALL && field[r+1][c] == EMPTY {
-					field[r][c] = EMPTY
-					field[r+1][c] = BALL
-					moveCount++
-				}
-			}
-		}
-	}
-}
-
-func tiltEast() {
-	moveCount := 99
-	for moveCount > 0 {
-		moveCount = 0
-		for c := COLS - 2; c >= 0; c-- {
-			for r := 0; r < ROWS; r++ {
-				if field[r][c] == BALL && field[r][c+1] == EMPTY {
-					field[r][c] = EMPTY
-					field[r][c+1] = BALL
-					moveCount++
-				}
-			}
-		}
-	}
-}
-
-func tiltWest() {
-	moveCount := 99
-	for moveCount > 0 {
-		moveCount = 0
-		for c := 1; c < COLS; c++ {
-			for r := 0; r < ROWS; r++ {
-				if field[r][c] == BALL && field[r][c-1] == EMPTY {
-					field[r][c] = EMPTY
-					field[r][c-1] = BALL
-					moveCount++
-				}
-			}
-		}
-	}
-}
-
-func countFromNorth() int {
+func countFromNorth(field *grid.Grid[byte]) int {
 	result := 0
-	for r := 0; r < ROWS; r++ {
-		for c := 0; c < COLS; c++ {
-			if field[r][c] == BALL {
-				result += (ROWS - r) * 1
-			}
+	field.Each(func(p grid.Point, value byte) {
+		if value == BALL {
+			result += (field.Rows() - p.Row) * 1
 		}
-	}
+	})
 	return result
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-func SolvePart1() int {
-	tiltNorth()
-	result := countFromNorth()
+func SolvePart1(field *grid.Grid[byte]) int {
+	tilt(field, grid.North)
+	result := countFromNorth(field)
 	return result
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-func SolvePart2() int {
-	LOOP_COUNT := 1000000000
-	doingRest := false
-	for i := 0; i < LOOP_COUNT; i++ {
-		tiltNorth()
-		tiltWest()
-		tiltSouth()
-		tiltEast()
-		hash := HashField()
-
-		if loopStart, ok := cache[hash]; ok && !doingRest {
-			loopLength := i - loopStart
-			rest := (LOOP_COUNT - loopStart) % loopLength
-			i = LOOP_COUNT - loopLength - rest
-			doingRest = true
-		}
-		cache[hash] = i
+const LOOP_COUNT = 1000000000
 
+// one spin cycle tilts a copy of the field north, west, south and east
+func spinCycle(field *grid.Grid[byte]) *grid.Grid[byte] {
+	next := field.Clone()
+	for _, dir := range []grid.Point{grid.North, grid.West, grid.South, grid.East} {
+		tilt(next, dir)
 	}
-
-	result := countFromNorth()
-	return result
+	return next
 }
 
-// -------------------------- Main entry -------------------------------------
+// the fields repeat after some spin cycles, so the field after all cycles
+// is one of the first few
+func SolvePart2(field *grid.Grid[byte]) int {
+	cycle := utils.FindCycle(field, spinCycle, (*grid.Grid[byte]).String)
+	//fmt.Printf("Cycle starts at %d with length %d\n", cycle.Start, cycle.Length)
 
-func main() {
-	var input []string
+	result := countFromNorth(cycle.At(LOOP_COUNT))
+	return result
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+// -------------------------- Solver entry -----------------------------------
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func init() {
+	puzzle.Register(14, func() puzzle.Solver { return &solver{} })
+}
 
-	inputLineToValues(input)
-	result1 := SolvePart1()
+type solver struct {
+	field *grid.Grid[byte]
+}
 
-	// reset field
-	inputLineToValues(input)
-	result2 := SolvePart2()
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.field, err = inputToField(input)
+	return err
+}
 
-	stopwatch.Stop()
+// each part tilts its own copy of the field
+func (s *solver) Part1() any {
+	return SolvePart1(s.field.Clone())
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.field.Clone())
 }
@@ -0,0 +1,11 @@
+package day15
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func BenchmarkParse(b *testing.B) { puzzletest.BenchmarkParse(b, 15) }
+func BenchmarkPart1(b *testing.B) { puzzletest.BenchmarkPart(b, 15, 1) }
+func BenchmarkPart2(b *testing.B) { puzzletest.BenchmarkPart(b, 15, 2) }
@@ -1,6 +1,6 @@
 // ---------------------------------------------------------------------------
-// Golang solution for Advent of Code 2023 day ..
-// https://adventofcode.com/2023/day/..
+// Golang solution for Advent of Code 2023 day 15
+// https://adventofcode.com/2023/day/15
 //
 // This was created using copilot to assist me in learning Go.
 //
@@ -9,31 +9,29 @@
 // Part 1:
 // Part 2:
 // ---------------------------------------------------------------------------
-package main
+package day15
 
 import (
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
+// number of boxes, the hash of a label is the box it goes into
+const BOX_COUNT int = 256
+
 func hash(input string) int {
 	var result int = 0
 	for _, c := range input {
 		result += int(c)
 		result *= 17
-		result %= 256
+		result %= BOX_COUNT
 	}
 	return result
 }
@@ -44,7 +42,7 @@ func SolvePart1(input string) int {
 	for _, step := range steps {
 		h := hash(step)
 		result += h
-		fmt.Printf("step: %s, hash: %d\n", step, h)
+		//fmt.Printf("step: %s, hash: %d\n", step, h)
 	}
 	return result
 }
@@ -61,29 +59,56 @@ type Box struct {
 	lenses []Lens
 }
 
-var boxes = make([]Box, 256)
+type Step struct {
+	label    string
+	operator string
+	value    int
+}
 
 // anything before '=' or '-' is returned as label
 // operator is returned as '=' or '-'
 // in case of '=' there is a value that follows
-func parseStep(step string) (string, string, int) {
-	var label string = ""
+func parseStep(step string) (Step, error) {
+	var label string = step
 	var operator string = ""
 	var value int = -1
 
-	for _, c := range step {
-		if c == '=' || c == '-' {
-			operator = string(c)
-			break
-		}
-		label += string(c)
+	// byte offsets, ranging over the runes would turn invalid UTF-8 into longer runes
+	if idx := strings.IndexAny(step, "=-"); idx >= 0 {
+		label = step[:idx]
+		operator = step[idx : idx+1]
 	}
 
-	if operator == "=" {
-		value = utils.StringToInt(step[len(label)+1:])
+	switch {
+	case label == "":
+		return Step{}, fmt.Errorf("step %q has no label", step)
+	case operator == "":
+		return Step{}, fmt.Errorf("step %q has no operator", step)
+	case operator == "-" && len(step) > len(label)+1:
+		return Step{}, fmt.Errorf("step %q has a value after -", step)
+	case operator == "=":
+		var err error
+		if value, err = utils.StringToInt(step[len(label)+1:]); err != nil {
+			return Step{}, err
+		}
 	}
 
-	return label, operator, value
+	return Step{label: label, operator: operator, value: value}, nil
+}
+
+// steps are separated by ',' on a single line
+func parseSteps(line utils.Line) ([]Step, error) {
+	var steps []Step
+	offset := 0
+	for _, step := range strings.Split(line.Text, ",") {
+		parsed, err := parseStep(step)
+		if err != nil {
+			return nil, line.ErrorfAt(offset, "%v", err)
+		}
+		steps = append(steps, parsed)
+		offset += len(step) + 1
+	}
+	return steps, nil
 }
 
 func hasLens(box Box, lens Lens) int {
@@ -95,15 +120,14 @@ func hasLens(box Box, lens Lens) int {
 	return -1
 }
 
-func SolvePart2(input string) int {
+func SolvePart2(steps []Step) int {
 	var result int = 0
-	steps := strings.Split(input, ",")
+	boxes := make([]Box, BOX_COUNT)
 	for _, step := range steps {
-		label, operator, value := parseStep(step)
-		lens := Lens{label: label, focal: value}
-		boxID := hash(label)
+		lens := Lens{label: step.label, focal: step.value}
+		boxID := hash(step.label)
 		lensPosition := hasLens(boxes[boxID], lens)
-		switch operator {
+		switch step.operator {
 		case "=":
 			if lensPosition >= 0 {
 				boxes[boxID].lenses[lensPosition].focal = lens.focal
@@ -129,36 +153,38 @@ func SolvePart2(input string) int {
 		}
 	}
 
-	for id, focal := range lens_focus {
+	for _, focal := range lens_focus {
 		result += focal
-		fmt.Printf("focal: %s, %d\n", id, focal)
 	}
 
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(15, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	input string
+	steps []Step
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Parse(input []string) error {
+	if len(input) != 1 {
+		return fmt.Errorf("expected a single line, got %d", len(input))
 	}
+	s.input = input[0]
+	var err error
+	s.steps, err = parseSteps(utils.NewLine(0, input[0]))
+	return err
+}
 
-	result1 := SolvePart1(input[0])
-	result2 := SolvePart2(input[0])
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePart1(s.input)
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.steps)
 }
@@ -0,0 +1,11 @@
+package day16
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func BenchmarkParse(b *testing.B) { puzzletest.BenchmarkParse(b, 16) }
+func BenchmarkPart1(b *testing.B) { puzzletest.BenchmarkPart(b, 16, 1) }
+func BenchmarkPart2(b *testing.B) { puzzletest.BenchmarkPart(b, 16, 2) }
@@ -22,264 +22,139 @@
 // Part 1: count the number of energized tiles after the laser has passed through
 // Part 2: find the start position that leads to the highest number of energized tiles
 // ---------------------------------------------------------------------------
-package main
+package day16
 
 import (
-	"fmt"
-	"hash/fnv"
-	"time"
-
-	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Data Section ----------------------------
 
-type Tile struct {
-	IsEnergized          bool
-	IsSplitterNorthSouth bool
-	IsSplitterEastWest   bool
-	IsMirrorUpRight      bool
-	IsMirrorDownRight    bool
-	IsFloor              bool
-}
-
-type Direction int
-
 const (
-	NORTH Direction = iota
-	EAST
-	WEST
-	SOUTH
+	FLOOR                = '.'
+	SPLITTER_NORTH_SOUTH = '|'
+	SPLITTER_EAST_WEST   = '-'
+	MIRROR_UP_RIGHT      = '/'
+	MIRROR_DOWN_RIGHT    = '\\'
 )
 
 type Laser struct {
-	Row       int
-	Col       int
-	direction Direction
+	pos       grid.Point
+	direction grid.Point
 }
 
-var cache = make(map[uint32]int)
-var beams []Laser
-var field [][]Tile
-var ROWS int
-var COLS int
+// state of one run of the laser through the field
+type contraption struct {
+	field     *grid.Grid[byte]
+	energized *grid.Grid[bool]
+	cache     map[Laser]bool
+	beams     []Laser
+}
 
 // -------------------------- Common Code Section ----------------------------
 
-func HashBeam(beam Laser) uint32 {
-	hash := fnv.New32()
-	hash.Write([]byte(fmt.Sprintf("%v", beam)))
-	return hash.Sum32()
+func inputToField(input []string) (*grid.Grid[byte], error) {
+	return grid.ParseOf(input, ".|-/\\")
 }
 
-func inputLineToValues(input []string) {
-	ROWS = len(input)
-	COLS = len(input[0])
-	field = make([][]Tile, ROWS)
-	for i := range field {
-		field[i] = make([]Tile, COLS)
-	}
-
-	for r, line := range input {
-		for c, char := range line {
-			tile := Tile{IsEnergized: false, IsSplitterNorthSouth: false, IsSplitterEastWest: false, IsMirrorUpRight: false, IsMirrorDownRight: false, IsFloor: true}
-			switch char {
-			case '.':
-				tile.IsFloor = true
-			case '/':
-				tile.IsMirrorUpRight = true
-			case '\\':
-				tile.IsMirrorDownRight = true
-			case '-':
-				tile.IsSplitterEastWest = true
-			case '|':
-				tile.IsSplitterNorthSouth = true
-			}
-			field[r][c] = tile
-		}
+func newContraption(field *grid.Grid[byte]) *contraption {
+	return &contraption{
+		field:     field,
+		energized: grid.New(field.Rows(), field.Cols(), false),
+		cache:     make(map[Laser]bool),
 	}
 }
 
-func printField() {
-	for r := 0; r < ROWS; r++ {
-		for c := 0; c < COLS; c++ {
-			tile := field[r][c]
-			if tile.IsEnergized {
-				fmt.Print("X")
-			} else {
-				fmt.Print(".")
-			}
-		}
-		fmt.Println()
-	}
-	fmt.Printf("\n\n")
-}
-
-func addBeam(row int, col int, direction Direction) {
-	beam := Laser{Row: row, Col: col, direction: direction}
-	beamHash := HashBeam(beam)
-	if _, ok := cache[beamHash]; ok {
+func (c *contraption) addBeam(pos grid.Point, direction grid.Point) {
+	beam := Laser{pos: pos, direction: direction}
+	if c.cache[beam] {
 		// beam has been seen before
 		return
 	}
-	cache[beamHash] = 1
-	beams = append(beams, beam)
+	c.cache[beam] = true
+	c.beams = append(c.beams, beam)
 
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-// todo: cover loops
-func runBeamTillEnd(beam Laser) {
+func (c *contraption) runBeamTillEnd(beam Laser) {
 	var positionCache = make(map[Laser]bool)
 	notInLoop := true
 	for notInLoop {
-		tile := &field[beam.Row][beam.Col]
-		tile.IsEnergized = true
-
-		if tile.IsMirrorDownRight {
-			switch beam.direction {
-			case NORTH:
-				beam.direction = WEST
-			case EAST:
-				beam.direction = SOUTH
-			case WEST:
-				beam.direction = NORTH
-			case SOUTH:
-				beam.direction = EAST
+		c.energized.Set(beam.pos, true)
+		tile := c.field.At(beam.pos)
+
+		switch {
+		case tile == MIRROR_DOWN_RIGHT:
+			// north becomes west, east becomes south
+			beam.direction = grid.Point{Row: beam.direction.Col, Col: beam.direction.Row}
+		case tile == MIRROR_UP_RIGHT:
+			// north becomes east, east becomes north
+			beam.direction = grid.Point{Row: -beam.direction.Col, Col: -beam.direction.Row}
+		case tile == SPLITTER_EAST_WEST && beam.direction.Col == 0:
+			// north / south headed, continue east and put a new beam west into beams
+			beam.direction = grid.East
+			if west := beam.pos.Add(grid.West); c.field.InBounds(west) {
+				c.addBeam(west, grid.West)
 			}
-		}
-
-		if tile.IsMirrorUpRight {
-			switch beam.direction {
-			case NORTH:
-				beam.direction = EAST
-			case EAST:
-				beam.direction = NORTH
-			case WEST:
-				beam.direction = SOUTH
-			case SOUTH:
-				beam.direction = WEST
-			}
-		}
-
-		// create a new beam if we're north / south headed and put it into beams
-		// else treat as normal field and pass through
-		if tile.IsSplitterEastWest && (beam.direction == NORTH || beam.direction == SOUTH) {
-			beam.direction = EAST
-			if beam.Col > 0 {
-				addBeam(beam.Row, beam.Col-1, WEST)
+		case tile == SPLITTER_NORTH_SOUTH && beam.direction.Row == 0:
+			// east / west headed, continue north and put a new beam south into beams
+			beam.direction = grid.North
+			if south := beam.pos.Add(grid.South); c.field.InBounds(south) {
+				c.addBeam(south, grid.South)
 			}
 		}
 
-		// create a new beam if we're east / west headed and put it into beams
-		// else treat as normal field and pass through
-		if tile.IsSplitterNorthSouth && (beam.direction == EAST || beam.direction == WEST) {
-			beam.direction = NORTH
-			if beam.Row < ROWS-1 {
-				addBeam(beam.Row+1, beam.Col, SOUTH)
-			}
-		}
-
-		switch beam.direction {
-		case NORTH:
-			beam.Row--
-		case EAST:
-			beam.Col++
-		case WEST:
-			beam.Col--
-		case SOUTH:
-			beam.Row++
-		}
-		if _, ok := positionCache[beam]; ok {
+		beam.pos = beam.pos.Add(beam.direction)
+		if positionCache[beam] {
 			// beam has been seen before at this position with this direction
 			notInLoop = false
 		}
-		positionCache[Laser{Row: beam.Row, Col: beam.Col, direction: beam.direction}] = true
+		positionCache[beam] = true
 
-		if beam.Row < 0 || beam.Row >= ROWS || beam.Col < 0 || beam.Col >= COLS {
+		if !c.field.InBounds(beam.pos) {
 			// beam has left the field
 			return
 		}
 	}
 }
 
-func countEnergy() int {
-	var result int
-	for r := 0; r < ROWS; r++ {
-		for c := 0; c < COLS; c++ {
-			if field[r][c].IsEnergized {
-				result++
-			}
-		}
+func (c *contraption) run(start Laser) int {
+	c.beams = append(c.beams, start)
+	for len(c.beams) > 0 {
+		beam := c.beams[0]
+		c.beams = c.beams[1:]
+		c.runBeamTillEnd(beam)
 	}
-	return result
-}
 
-func SolvePart1() int {
-	beam := Laser{Row: 0, Col: 0, direction: EAST}
-	beams = append(beams, beam)
-
-	for len(beams) > 0 {
-		beam := beams[0]
-		beams = beams[1:]
-		runBeamTillEnd(beam)
-	}
+	//fmt.Print(c.energized)
+	return c.energized.Count(func(e bool) bool { return e })
+}
 
-	printField()
-	return countEnergy()
+func SolvePart1(field *grid.Grid[byte]) int {
+	return newContraption(field).run(Laser{pos: grid.Point{Row: 0, Col: 0}, direction: grid.East})
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-func solveWithStart(beam Laser, input []string) int {
-	inputLineToValues(input)
-	cache = make(map[uint32]int)
-	beams = append(beams, beam)
-	for len(beams) > 0 {
-		beam := beams[0]
-		beams = beams[1:]
-		runBeamTillEnd(beam)
+func SolvePart2(field *grid.Grid[byte]) int {
+	var starts []Laser
+	for r := 0; r < field.Rows(); r++ {
+		// left to right and right to left
+		starts = append(starts, Laser{pos: grid.Point{Row: r, Col: 0}, direction: grid.East})
+		starts = append(starts, Laser{pos: grid.Point{Row: r, Col: field.Cols() - 1}, direction: grid.West})
+	}
+	for c := 0; c < field.Cols(); c++ {
+		// top to bottom and bottom to top
+		starts = append(starts, Laser{pos: grid.Point{Row: 0, Col: c}, direction: grid.South})
+		starts = append(starts, Laser{pos: grid.Point{Row: field.Rows() - 1, Col: c}, direction: grid.North})
 	}
 
-	return countEnergy()
-}
-
-func SolvePart2(input []string) int {
 	var result int = 0
-	// left to right
-	for r := 0; r < ROWS; r++ {
-		beam := Laser{Row: r, Col: 0, direction: EAST}
-		count := solveWithStart(beam, input)
-		if count > result {
-			result = count
-		}
-	}
-	// right to left
-	for r := 0; r < ROWS; r++ {
-		beam := Laser{Row: r, Col: COLS - 1, direction: WEST}
-		count := solveWithStart(beam, input)
-		if count > result {
-			result = count
-		}
-	}
-	// top to bottom
-	for c := 0; c < COLS; c++ {
-		beam := Laser{Row: 0, Col: c, direction: SOUTH}
-		count := solveWithStart(beam, input)
-		if count > result {
-			result = count
-		}
-	}
-	// bottom to top
-	for c := 0; c < COLS; c++ {
-		beam := Laser{Row: ROWS - 1, Col: c, direction: NORTH}
-		count := solveWithStart(beam, input)
+	for _, start := range starts {
+		count := newContraption(field).run(start)
 		if count > result {
 			result = count
 		}
@@ -288,30 +163,26 @@ func SolvePart2(input []string) int {
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func main() {
-	var input []string
-
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func init() {
+	puzzle.Register(16, func() puzzle.Solver { return &solver{} })
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+type solver struct {
+	field *grid.Grid[byte]
+}
 
-	inputLineToValues(input)
-	result1 := SolvePart1()
-	result2 := SolvePart2(input)
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.field, err = inputToField(input)
+	return err
+}
 
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePart1(s.field)
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.field)
 }
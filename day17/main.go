@@ -24,290 +24,152 @@
 // took inspiration from
 // https://www.reddit.com/r/adventofcode/comments/18luw6q/2023_day_17_a_longform_tutorial_on_day_17/
 // ---------------------------------------------------------------------------
-package main
+package day17
 
 import (
-	"container/heap"
 	"fmt"
-	"math"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
-var MAX_STREAK = 10 // set to 3 part 1
-var MIN_STREAK = 4  // set to 1 part 1
-
 // -------------------------- Common Data Section ----------------------------
 
-const MAX_STRAIGHT = 3
-
-var heatLoss [][]int
-var ROWS int
-var COLS int
-
-type Position struct {
-	r int
-	c int
-}
-
-type Direction int
-
-const (
-	NORTH Direction = iota
-	EAST
-	SOUTH
-	WEST
-)
-
-// -------------------------- Queue Shit -------------------------------------
-
-// PriorityQueue implements heap.Interface and holds States.
-type PriorityQueue []*State
-
-func (pq PriorityQueue) Len() int { return len(pq) }
-
-func (pq PriorityQueue) Less(i, j int) bool {
-	return pq[i].streak < pq[j].streak
-}
-
-func (pq PriorityQueue) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
-
-func (pq *PriorityQueue) Push(x interface{}) {
-	item := x.(*State)
-	*pq = append(*pq, item)
-}
-
-func (pq *PriorityQueue) Pop() interface{} {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	*pq = old[0 : n-1]
-	return item
-}
-
-type StateQueue struct {
-	pq PriorityQueue
-}
-
-func NewStateQueue() *StateQueue {
-	return &StateQueue{pq: make(PriorityQueue, 0)}
-}
-
-func (q *StateQueue) Enqueue(state *State) {
-	heap.Push(&q.pq, state)
-}
-
-func (q *StateQueue) Dequeue() *State {
-	if len(q.pq) == 0 {
-		return nil
-	}
-	return heap.Pop(&q.pq).(*State)
+// streak limits of a crucible
+type Crucible struct {
+	minStreak int
+	maxStreak int
 }
 
 // -------------------------- Common Code Section ----------------------------
 
-func inputLineToValues(input []string) {
-	ROWS = len(input)
-	COLS = len(input[0])
-	heatLoss = make([][]int, ROWS)
-	for i := range heatLoss {
-		heatLoss[i] = make([]int, COLS)
-	}
-
-	for r, line := range input {
-		for c, char := range line {
-			heatLoss[r][c] = int(char) - int('0')
+func inputToHeatLoss(input []string) (*grid.Grid[int], error) {
+	return grid.Parse(input, func(char byte) (int, error) {
+		if char < '0' || char > '9' {
+			return 0, fmt.Errorf("expected a digit, got %q", char)
 		}
-	}
+		return int(char) - int('0'), nil
+	})
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
 type State struct {
-	position Position
-	dir      Direction
+	position grid.Point
+	dir      grid.Point
 	streak   int
 }
 
-func getNextValidMoves(currentState *State) []Position {
-	var validMoves []Position
-	var neighbors []Position
-	pos := currentState.position
+// returns the directions the crucible can move next
+func (crucible Crucible) getNextValidMoves(heatLoss *grid.Grid[int], currentState *State) []grid.Point {
+	var validMoves []grid.Point
+	var directions []grid.Point
 
 	// don't go back, don't exceed streak
-	switch currentState.dir {
-	case NORTH:
-		if currentState.streak < MIN_STREAK {
-			// must go north
-			neighbors = []Position{{pos.r - 1, pos.c}}
-		} else {
-			if currentState.streak >= MAX_STREAK {
-				// skip north and south
-				neighbors = []Position{{pos.r, pos.c + 1}, {pos.r, pos.c - 1}}
-			} else {
-				// skip south
-				neighbors = []Position{{pos.r + 1, pos.c}, {pos.r, pos.c + 1}, {pos.r, pos.c - 1}}
-			}
-		}
-	case EAST:
-		if currentState.streak < MIN_STREAK {
-			// must go east
-			neighbors = []Position{{pos.r, pos.c + 1}}
-		} else {
-			if currentState.streak >= MAX_STREAK {
-				// skip east and west
-				neighbors = []Position{{pos.r - 1, pos.c}, {pos.r + 1, pos.c}}
-			} else {
-				// skip west
-				neighbors = []Position{{pos.r - 1, pos.c}, {pos.r, pos.c + 1}, {pos.r + 1, pos.c}}
-			}
-		}
-	case SOUTH:
-		if currentState.streak < MIN_STREAK {
-			// must go south
-			neighbors = []Position{{pos.r + 1, pos.c}}
-		} else {
-			if currentState.streak >= MAX_STREAK {
-				// skip north and south
-				neighbors = []Position{{pos.r, pos.c + 1}, {pos.r, pos.c - 1}}
-			} else {
-				// skip north
-				neighbors = []Position{{pos.r, pos.c + 1}, {pos.r + 1, pos.c}, {pos.r, pos.c - 1}}
-			}
-		}
-	case WEST:
-		if currentState.streak < MIN_STREAK {
-			// must go west
-			neighbors = []Position{{pos.r, pos.c - 1}}
-		} else {
-			if currentState.streak >= MAX_STREAK {
-				// skip east and west
-				neighbors = []Position{{pos.r - 1, pos.c}, {pos.r + 1, pos.c}}
-			} else {
-				// skip east
-				neighbors = []Position{{pos.r - 1, pos.c}, {pos.r + 1, pos.c}, {pos.r, pos.c - 1}}
-			}
-		}
+	heading := currentState.dir
+	switch {
+	case currentState.streak < crucible.minStreak:
+		directions = []grid.Point{heading}
+	case currentState.streak >= crucible.maxStreak:
+		directions = []grid.Point{heading.TurnLeft(), heading.TurnRight()}
+	default:
+		directions = []grid.Point{heading, heading.TurnLeft(), heading.TurnRight()}
 	}
 
-	for _, neighbor := range neighbors {
-		if neighbor.r >= 0 && neighbor.r < ROWS && neighbor.c >= 0 && neighbor.c < COLS {
-			validMoves = append(validMoves, neighbor)
+	for _, dir := range directions {
+		if heatLoss.InBounds(currentState.position.Add(dir)) {
+			validMoves = append(validMoves, dir)
 		}
 	}
 	return validMoves
 }
 
-func getDirection(currentPos Position, nextPos Position) Direction {
-	if nextPos.r < currentPos.r {
-		return NORTH
+// moves to the next blocks, the cost of a move is the heat loss of the block entered
+func (crucible Crucible) nextStates(heatLoss *grid.Grid[int]) func(State) []utils.Edge[State] {
+	return func(currentState State) []utils.Edge[State] {
+		var edges []utils.Edge[State]
+		for _, direction := range crucible.getNextValidMoves(heatLoss, &currentState) {
+			move := currentState.position.Add(direction)
+			streak := 1
+			if currentState.dir == direction {
+				streak = currentState.streak + 1
+			}
+			nextState := State{position: move, dir: direction, streak: streak}
+			edges = append(edges, utils.Edge[State]{To: nextState, Cost: heatLoss.At(move)})
+		}
+		return edges
 	}
-	if nextPos.c > currentPos.c {
-		return EAST
+}
+
+// returns an error if no path ends with a streak the crucible can stop after
+func (crucible Crucible) findPath(heatLoss *grid.Grid[int], start grid.Point, end grid.Point) (int, error) {
+	// the crucible does not move, the start block's heat loss does not count
+	if start == end {
+		return 0, nil
 	}
-	if nextPos.r > currentPos.r {
-		return SOUTH
+	// no block moved yet, the minimum streak applies from the first move
+	starts := []State{
+		{position: start, dir: grid.East, streak: 0},
+		{position: start, dir: grid.South, streak: 0},
 	}
-	if nextPos.c < currentPos.c {
-		return WEST
+	isEnd := func(state State) bool {
+		return state.position == end && state.streak >= crucible.minStreak
 	}
-	return NORTH
-}
 
-func lowestCostInStateQueue(stateQueueByCost map[int]*StateQueue) int {
-	var lowestCost int = math.MaxInt32
-	for cost := range stateQueueByCost {
-		if cost < lowestCost && len(stateQueueByCost[cost].pq) > 0 {
-			lowestCost = cost
-		}
+	path, ok := utils.ShortestPath(starts, crucible.nextStates(heatLoss), isEnd)
+	if !ok {
+		return 0, fmt.Errorf("end %v can not be reached", end)
 	}
-	return lowestCost
+	//fmt.Printf("Path: %v\n", path.States)
+	return path.Cost, nil
 }
 
-func findPath(start Position, end Position) int {
-	stateQueueByCost := make(map[int]*StateQueue)
-	costByStateCache := make(map[State]int)
-
-	startEast := State{position: Position{0, 0}, dir: EAST, streak: 1}
-	startSouth := State{position: Position{0, 0}, dir: SOUTH, streak: 1}
-
-	costByStateCache[startEast] = 0
-	costByStateCache[startSouth] = 0
-
-	queue := NewStateQueue()
-	queue.Enqueue(&startEast)
-	queue.Enqueue(&startSouth)
-
-	stateQueueByCost[0] = queue
-
-	for {
-		lowestCost := lowestCostInStateQueue(stateQueueByCost)
-		queue := stateQueueByCost[lowestCost]
-
-		for len(queue.pq) > 0 {
-			currentState := queue.Dequeue()
+func SolvePart1(heatLoss *grid.Grid[int]) (int, error) {
+	crucible := Crucible{minStreak: 1, maxStreak: 3}
+	start := grid.Point{Row: 0, Col: 0}
+	end := grid.Point{Row: heatLoss.Rows() - 1, Col: heatLoss.Cols() - 1}
+	return crucible.findPath(heatLoss, start, end)
+}
 
-			if currentState.position == end && currentState.streak >= MIN_STREAK {
-				// TODO: don't break yet, test all other paths with same length
-				fmt.Printf("Current state: %v\n", currentState)
-				fmt.Printf("Heat loss: %v\n", lowestCost)
-				return lowestCost
-			} else {
-				// explore all possible moves
-				moveList := getNextValidMoves(currentState)
-				for _, move := range moveList {
+// -------------------------- Puzzle part 2 ----------------------------------
 
-					// create next state
-					direction := getDirection(currentState.position, move)
-					streak := 1
-					if currentState.dir == direction {
-						streak = currentState.streak + 1
-					}
-					tmpHeatLoss := lowestCost + heatLoss[move.r][move.c]
-					tmpState := State{position: move, dir: direction, streak: streak}
+// ultra crucible, at least 4 and at most 10 blocks before turning
+func SolvePart2(heatLoss *grid.Grid[int]) (int, error) {
+	crucible := Crucible{minStreak: 4, maxStreak: 10}
+	start := grid.Point{Row: 0, Col: 0}
+	end := grid.Point{Row: heatLoss.Rows() - 1, Col: heatLoss.Cols() - 1}
+	return crucible.findPath(heatLoss, start, end)
+}
 
-					if _, exists := costByStateCache[tmpState]; !exists {
-						costByStateCache[tmpState] = tmpHeatLoss
+// -------------------------- Solver entry -----------------------------------
 
-						if _, exists := stateQueueByCost[tmpHeatLoss]; !exists {
-							stateQueueByCost[tmpHeatLoss] = NewStateQueue()
-						}
-						queueByCost := stateQueueByCost[tmpHeatLoss]
-						queueByCost.Enqueue(&tmpState)
-					}
-				}
-			}
-		}
-	}
+func init() {
+	puzzle.Register(17, func() puzzle.Solver { return &solver{} })
 }
 
-func SolvePart1() int {
-	start := Position{0, 0}
-	end := Position{ROWS - 1, COLS - 1}
-	cost := findPath(start, end)
-	return cost
+type solver struct {
+	heatLoss *grid.Grid[int]
 }
 
-// -------------------------- Main entry -------------------------------------
-
-func main() {
-	var input []string
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.heatLoss, err = inputToHeatLoss(input)
+	return err
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Part1() any {
+	result, err := SolvePart1(s.heatLoss)
+	if err != nil {
+		return err
 	}
+	return result
+}
 
-	inputLineToValues(input)
-	result1 := SolvePart1()
-
-	// ---------------------- Print results ----------------------------------
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
+func (s *solver) Part2() any {
+	result, err := SolvePart2(s.heatLoss)
+	if err != nil {
+		return err
+	}
+	return result
 }
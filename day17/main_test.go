@@ -0,0 +1,115 @@
+package day17
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
+)
+
+func TestSolvePart2(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected int
+	}{
+		{"example", []string{
+			"2413432311323",
+			"3215453535623",
+			"3255245654254",
+			"3446585845452",
+			"4546657867536",
+			"1438598798454",
+			"4457876987766",
+			"3637877979653",
+			"4654967986887",
+			"4564679986453",
+			"1224686865563",
+			"2546548887735",
+			"4322674655533",
+		}, 94},
+		// the ultra crucible has to move 4 blocks before it can stop at the end
+		{"second example", []string{
+			"111111111111",
+			"999999999991",
+			"999999999991",
+			"999999999991",
+			"999999999991",
+		}, 71},
+		// the cheap path turns after 3 blocks from the start, which is too early
+		{"first turn", []string{
+			"11119999",
+			"99919999",
+			"99919999",
+			"99919999",
+			"99911111",
+		}, 59},
+	}
+
+	for _, test := range tests {
+		heatLoss, err := inputToHeatLoss(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := SolvePart2(heatLoss)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if result != test.expected {
+			t.Errorf("%s: Expected %d, but got %d", test.name, test.expected, result)
+		}
+	}
+}
+
+// a single block is start and end, grids too small for a crucible have no
+// path, -1 expects an error
+func TestSmallGrids(t *testing.T) {
+	tests := []struct {
+		input []string
+		part1 int
+		part2 int
+	}{
+		{[]string{"5"}, 0, 0},
+		{[]string{"12"}, 2, -1},
+		{[]string{"12", "34"}, 6, -1},
+		{[]string{"12345"}, -1, 14},
+	}
+
+	for _, test := range tests {
+		heatLoss, err := inputToHeatLoss(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for part, solve := range []func(*grid.Grid[int]) (int, error){SolvePart1, SolvePart2} {
+			expected := []int{test.part1, test.part2}[part]
+			result, err := solve(heatLoss)
+			if expected < 0 && err == nil {
+				t.Errorf("%v part %d: Expected an error, but got %d", test.input, part+1, result)
+			} else if expected >= 0 && (err != nil || result != expected) {
+				t.Errorf("%v part %d: Expected %d, but got %d, %v", test.input, part+1, expected, result, err)
+			}
+		}
+	}
+}
+
+// in the middle of the grid a crucible never turns back, it goes on straight
+// until the streak is at its maximum
+func TestNextValidMoves(t *testing.T) {
+	heatLoss := grid.New(5, 5, 1)
+	crucible := Crucible{minStreak: 1, maxStreak: 3}
+	center := grid.Point{Row: 2, Col: 2}
+
+	for _, dir := range grid.Directions4 {
+		state := State{position: center, dir: dir, streak: 1}
+		expected := []grid.Point{dir, dir.TurnLeft(), dir.TurnRight()}
+		if moves := crucible.getNextValidMoves(heatLoss, &state); !reflect.DeepEqual(moves, expected) {
+			t.Errorf("%v streak 1: Expected %v, but got %v", dir, expected, moves)
+		}
+
+		state.streak = 3
+		expected = []grid.Point{dir.TurnLeft(), dir.TurnRight()}
+		if moves := crucible.getNextValidMoves(heatLoss, &state); !reflect.DeepEqual(moves, expected) {
+			t.Errorf("%v streak 3: Expected %v, but got %v", dir, expected, moves)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package day18
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle/puzzletest"
+)
+
+func BenchmarkParse(b *testing.B) { puzzletest.BenchmarkParse(b, 18) }
+func BenchmarkPart1(b *testing.B) { puzzletest.BenchmarkPart(b, 18, 1) }
+func BenchmarkPart2(b *testing.B) { puzzletest.BenchmarkPart(b, 18, 2) }
@@ -25,27 +25,24 @@
 //         first five hexadecimal digits = distance as a hexadecimal number
 //         last is direction: 0 = R, 1 = D, 2 = L, and 3 = U
 // ---------------------------------------------------------------------------
-package main
+package day18
 
 import (
-	"fmt"
-	"math"
+	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/geometry"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Data Section ----------------------------
 
 const EMPTY = 0
-const FILLED = 100
+const DUG = 1
+const OUTSIDE = 2
 
 type Instruction struct {
 	direction string
@@ -53,164 +50,100 @@ type Instruction struct {
 	color     string
 }
 
-type Position struct {
-	row int64
-	col int64
+var directions = map[string]grid.Point{
+	"U": grid.North,
+	"D": grid.South,
+	"L": grid.West,
+	"R": grid.East,
 }
 
-var digPlan []Instruction
-
-var field [][]byte
-var ROWS int
-var COLS int
-var min_row int64 = math.MaxInt64
-var min_col int64 = math.MaxInt64
-var max_row int64 = 0
-var max_col int64 = 0
-
 // -------------------------- Common Code Section ----------------------------
 
-func inputLineToData(input []string) {
-	ROWS = 2000
-	COLS = 2000
-	field = make([][]byte, ROWS)
+var colorPattern = regexp.MustCompile(`^\(#[0-9a-f]{5}[0-3]\)$`)
 
-	for i := range field {
-		field[i] = make([]byte, COLS)
-	}
-
-	for r, line := range input {
-		for c, _ := range line {
-			field[r][c] = EMPTY
-		}
-	}
+func inputLineToData(input []string) ([]Instruction, error) {
+	var digPlan []Instruction
 
-	for _, line := range input {
+	for idx, line := range input {
+		context := utils.NewLine(idx, line)
 		parts := strings.Split(line, " ")
+		if len(parts) != 3 {
+			return nil, context.Errorf("expected direction, distance and color")
+		}
 		dir := parts[0]
-		dis := utils.StringToInt(parts[1])
+		if _, ok := directions[dir]; !ok {
+			return nil, context.ErrorfAt(0, "direction must be U, D, L or R, got %q", dir)
+		}
+		dis, err := context.Int(len(dir)+1, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		if dis < 1 {
+			return nil, context.ErrorfAt(len(dir)+1, "distance must be positive, got %d", dis)
+		}
 		col := parts[2]
 		col = strings.Trim(col, " ")
-		digPlan = append(digPlan, Instruction{direction: dir, distance: dis, color: col})
-	}
-}
-
-func printField() {
-	for r := min_row; r <= max_row; r++ {
-		for c := min_col; c <= max_col; c++ {
-			if field[r][c] == EMPTY {
-				fmt.Printf(" ")
-			} else if field[r][c] == FILLED {
-				fmt.Printf("F")
-			} else {
-				fmt.Printf("%d", field[r][c])
-			}
+		if !colorPattern.MatchString(col) {
+			return nil, context.ErrorfAt(len(dir)+len(parts[1])+2, "expected color like (#70c710), got %q", col)
 		}
-		fmt.Println()
+		digPlan = append(digPlan, Instruction{direction: dir, distance: dis, color: col})
 	}
-	fmt.Println()
+	return digPlan, nil
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-func followInstructions() {
-	pos := Position{row: 1000, col: 1000}
-	field[pos.row][pos.col] = 1
-
+// returns the dug trench in a field with a border of one empty cell around it
+func followInstructions(digPlan []Instruction) *grid.Grid[byte] {
+	var pos, min, max grid.Point
 	for _, instruction := range digPlan {
-		switch instruction.direction {
-		case "U":
-			for x := 0; x < instruction.distance; x++ {
-				pos.row -= 1
-				field[pos.row][pos.col] += 1
-			}
-			if min_row > pos.row {
-				min_row = pos.row
-			}
-		case "D":
-			for x := 0; x < instruction.distance; x++ {
-				pos.row += 1
-				field[pos.row][pos.col] += 1
-			}
-			if max_row < pos.row {
-				max_row = pos.row
-			}
-		case "L":
-			for x := 0; x < instruction.distance; x++ {
-				pos.col -= 1
-				field[pos.row][pos.col] += 1
-			}
-			if min_col > pos.col {
-				min_col = pos.col
-			}
-		case "R":
-			for x := 0; x < instruction.distance; x++ {
-				pos.col += 1
-				field[pos.row][pos.col] += 1
-			}
-			if max_col < pos.col {
-				max_col = pos.col
-			}
-		}
+		pos = pos.Add(directions[instruction.direction].Scale(instruction.distance))
+		min = grid.Point{Row: utils.Min(min.Row, pos.Row), Col: utils.Min(min.Col, pos.Col)}
+		max = grid.Point{Row: utils.Max(max.Row, pos.Row), Col: utils.Max(max.Col, pos.Col)}
 	}
-}
 
-func floodFillStart() Position {
-	for r := min_row; r <= max_row; r++ {
-		for c := min_col; c <= max_col; c++ {
-			if field[r][c] > EMPTY && field[r][c+1] > EMPTY {
-				// discard row, hope we have an easy start next row
-				break
-			}
-			if field[r][c] > EMPTY && field[r][c+1] == EMPTY {
-				pos := Position{row: r, col: c + 1}
-				return pos
-			}
+	field := grid.New(max.Row-min.Row+3, max.Col-min.Col+3, byte(EMPTY))
+	pos = grid.Point{Row: 1 - min.Row, Col: 1 - min.Col}
+	field.Set(pos, DUG)
+	for _, instruction := range digPlan {
+		for x := 0; x < instruction.distance; x++ {
+			pos = pos.Add(directions[instruction.direction])
+			field.Set(pos, DUG)
 		}
 	}
-	panic("Failed floodFillStart()")
-}
-
-func floodFill(pos Position) {
-	if field[pos.row][pos.col] != EMPTY {
-		return
-	}
-	field[pos.row][pos.col] = FILLED
-	floodFill(Position{row: pos.row - 1, col: pos.col})
-	floodFill(Position{row: pos.row + 1, col: pos.col})
-	floodFill(Position{row: pos.row, col: pos.col + 1})
-	floodFill(Position{row: pos.row, col: pos.col - 1})
+	return field
 }
 
-func countArea() int {
-	cnt := 0
-	for r := min_row; r <= max_row; r++ {
-		for c := min_col; c <= max_col; c++ {
-			if field[r][c] > 0 {
-				cnt++
+// marks everything outside of the trench starting at the top left corner of
+// the border
+func fillOutside(field *grid.Grid[byte]) {
+	queue := []grid.Point{{Row: 0, Col: 0}}
+	field.Set(queue[0], OUTSIDE)
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		for _, next := range field.Neighbors4(pos) {
+			if field.At(next) == EMPTY {
+				field.Set(next, OUTSIDE)
+				queue = append(queue, next)
 			}
 		}
 	}
-	return cnt
 }
 
-func SolvePart1() int {
-	followInstructions()
-	//printField()
-	pos := floodFillStart()
-	floodFill(pos)
-	//printField()
-	result := countArea()
+func SolvePart1(digPlan []Instruction) int {
+	field := followInstructions(digPlan)
+	fillOutside(field)
+	isOutside := func(value byte) bool { return value == OUTSIDE }
+	result := field.Rows()*field.Cols() - field.Count(isOutside)
 	return result
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-var polygon []Position
-
-func updateDigPlanBasedOnColor() {
-	for x := 0; x < len(digPlan); x++ {
-		instruction := &digPlan[x]
+func updateDigPlanBasedOnColor(digPlan []Instruction) []Instruction {
+	result := make([]Instruction, len(digPlan))
+	for x, instruction := range digPlan {
 		lStr := instruction.color[2:7]
 		dStr := instruction.color[7 : len(instruction.color)-1]
 		switch dStr {
@@ -226,71 +159,56 @@ func updateDigPlanBasedOnColor() {
 		i, _ := strconv.ParseInt(lStr, 16, 64)
 		instruction.distance = int(i)
 		//fmt.Printf("length %d, dir %s\n", instruction.distance, dStr)
+		result[x] = instruction
 	}
+	return result
 }
 
-func calculatePolygonArea(points []Position) int64 {
-	var area int64 = 0
-	for x := 0; x < len(points)-1; x++ {
-		y := x + 1
-		p1 := points[x]
-		p2 := points[y]
-		area = area + int64(p1.row)*int64(p2.col) - int64(p1.col)*int64(p2.row)
-	}
-	area = area / 2
-	return area
-}
-
-// the field will be too big to fit into memory ... let's get smarter
-func SolvePart2() int64 {
-	updateDigPlanBasedOnColor()
-	pos := Position{row: 0, col: 0}
-	polygon = append(polygon, pos)
-	totalPolygonLength := int64(0)
+// the field will be too big to fit into memory ... let's get smarter.
+// The trench is the border of a polygon, the cubes are all integer points
+// inside or on it, Pick's theorem gives them from area and border.
+func SolvePart2(digPlan []Instruction) int {
+	digPlan = updateDigPlanBasedOnColor(digPlan)
+	pos := geometry.Point2{X: 0, Y: 0}
+	var polygon geometry.Polygon
 	for _, instruction := range digPlan {
+		polygon = append(polygon, pos)
 		switch instruction.direction {
 		case "U":
-			pos = Position{row: pos.row + int64(instruction.distance), col: pos.col}
+			pos = pos.Add(geometry.Point2{Y: instruction.distance})
 		case "D":
-			pos = Position{row: pos.row - int64(instruction.distance), col: pos.col}
+			pos = pos.Add(geometry.Point2{Y: -instruction.distance})
 		case "L":
-			pos = Position{row: pos.row, col: pos.col - int64(instruction.distance)}
+			pos = pos.Add(geometry.Point2{X: -instruction.distance})
 		case "R":
-			pos = Position{row: pos.row, col: pos.col + int64(instruction.distance)}
+			pos = pos.Add(geometry.Point2{X: instruction.distance})
 		}
-		totalPolygonLength += int64(instruction.distance)
-		polygon = append(polygon, pos)
 	}
 
-	result := calculatePolygonArea(polygon)
-	result += totalPolygonLength/2 + 1
-
+	result := polygon.LatticePoints()
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(18, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	digPlan []Instruction
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.digPlan, err = inputLineToData(input)
+	return err
+}
 
-	inputLineToData(input)
-	result1 := SolvePart1()
-	result2 := SolvePart2()
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePart1(s.digPlan)
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.digPlan)
 }
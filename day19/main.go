@@ -1,6 +1,6 @@
 // ---------------------------------------------------------------------------
-// Golang solution for Advent of Code 2023 day ..
-// https://adventofcode.com/2023/day/..
+// Golang solution for Advent of Code 2023 day 19
+// https://adventofcode.com/2023/day/19
 //
 // This was created using copilot to assist me in learning Go.
 //
@@ -26,22 +26,18 @@
 // Part 1: add together all of the ratings for all of the parts that get accepted
 // Part 2: find the range of possible inputs for all 4 attributes then multiply out the ranges
 // ---------------------------------------------------------------------------
-package main
+package day19
 
 import (
 	"fmt"
 	"regexp"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/graph"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Data Section ----------------------------
 
 type Condition struct {
@@ -65,8 +61,11 @@ type Part struct {
 	attr []Attribute
 }
 
-var parts []Part
-var workflow []Rule
+// System holds the workflows and the parts sent through them
+type System struct {
+	workflow []Rule
+	parts    []Part
+}
 
 func (r Rule) getUnconditionalState() string {
 	return r.conditions[len(r.conditions)-1].target
@@ -98,8 +97,8 @@ func (r Rule) nextState(part Part) string {
 	panic("nextState() not found")
 }
 
-func getRuleByName(name string) Rule {
-	for _, rule := range workflow {
+func (s *System) getRuleByName(name string) Rule {
+	for _, rule := range s.workflow {
 		if rule.name == name {
 			return rule
 		}
@@ -111,86 +110,153 @@ func getRuleByName(name string) Rule {
 
 // takes ex{x>10:one,m<20:two,a>30:R,A}
 // return ex and "x>10:one,m<20:two,a>30:R,A"
-func extractRuleName(line string) (string, string) {
-	idx := strings.Index(line, "{")
-	if idx == 0 {
-		panic("Invalid input, no { found}")
+func extractRuleName(line utils.Line) (string, utils.Line, error) {
+	idx := strings.Index(line.Text, "{")
+	if idx <= 0 || !strings.HasSuffix(line.Text, "}") {
+		return "", utils.Line{}, line.Errorf("expected workflow like ex{x>10:one,A}")
 	}
-	name := line[:idx]        // get name
-	line = line[idx+1:]       // remove name
-	line = line[:len(line)-1] // remove }
-	return name, line
+	name := line.Text[:idx]                      // get name
+	rules := line.Slice(idx+1, len(line.Text)-1) // remove name and }
+	return name, rules, nil
 }
 
-func parseInput(input []string) {
-	pattern := `([a-zA-Z]+)([<>]+)(\d+):`
-	regex := regexp.MustCompile(pattern)
+var conditionPattern = regexp.MustCompile(`^([xmas])([<>])(\d+):([a-zA-Z]+)$`)
+var attributeFormat = utils.MustLineFormat("%s=%d")
 
-	pattern2 := `([a-zA-Z]+)=(\d+)`
-	regex2 := regexp.MustCompile(pattern2)
+// workflows and parts are separated by a blank line
+func parseInput(input []string) (*System, error) {
+	sections := utils.Sections(input)
+	if len(sections) != 2 {
+		return nil, fmt.Errorf("expected workflows and parts separated by a blank line, got %d sections", len(sections))
+	}
 
-	var idx int
-	var row int
-	for row = 0; row < len(input) && input[row] != ""; row++ {
+	var system System
+	var err error
+	if system.workflow, err = parseWorkflows(sections[0]); err != nil {
+		return nil, err
+	}
+	if system.parts, err = parseParts(sections[1]); err != nil {
+		return nil, err
+	}
+	if err := checkTargets(sections[0], system.workflow); err != nil {
+		return nil, err
+	}
+	return &system, nil
+}
+
+func parseWorkflows(section utils.Section) ([]Rule, error) {
+	var workflow []Rule
+	for row := range section.Lines {
 		// parse rules into workflow
 		// ex{x>10:one,m<20:two,a>30:R,A}
 
-		line := input[row]
-		ruleName, line := extractRuleName(line)
+		line := section.Line(row)
+		ruleName, rules, err := extractRuleName(line)
+		if err != nil {
+			return nil, err
+		}
 		rule := Rule{name: ruleName} // get name
 
-		sections := strings.Split(line, ",")
-		for _, section := range sections {
+		sections := rules.Split(",")
+		for idx, section := range sections {
 			// conditions are optional, target is required
 			var condition Condition
-			if strings.Contains(section, ":") {
-				matches := regex.FindStringSubmatch(section)
-				condition.name = matches[1]
-				condition.op = matches[2]
-				condition.value = utils.StringToInt(matches[3])
-				idx = strings.Index(section, ":")
-				section = section[idx+1:]
+			target := section.Text
+			if strings.Contains(section.Text, ":") {
+				matches := conditionPattern.FindStringSubmatchIndex(section.Text)
+				if matches == nil || idx == len(sections)-1 {
+					return nil, line.Errorf("invalid condition %q", section.Text)
+				}
+				condition.name = section.Text[matches[2]:matches[3]]
+				condition.op = section.Text[matches[4]:matches[5]]
+				if condition.value, err = section.Int(matches[6], section.Text[matches[6]:matches[7]]); err != nil {
+					return nil, err
+				}
+				target = section.Text[matches[8]:matches[9]]
+			} else if idx != len(sections)-1 || section.Text == "" {
+				return nil, line.Errorf("only the last rule has no condition, got %q", section.Text)
 			}
-			condition.target = section
+			condition.target = target
 			rule.conditions = append(rule.conditions, condition)
 		}
 		workflow = append(workflow, rule)
 	}
+	return workflow, nil
+}
 
-	for row++; row < len(input); row++ {
+func parseParts(section utils.Section) ([]Part, error) {
+	var parts []Part
+	for row := range section.Lines {
 		// parse parts
 		// {x:1,m:2,a:3,s:4}
 		var part Part
-		line := input[row][1 : len(input[row])-1] // remove {}
-		sections := strings.Split(line, ",")
+		line := section.Line(row)
+		if !strings.HasPrefix(line.Text, "{") || !strings.HasSuffix(line.Text, "}") || len(line.Text) < 2 {
+			return nil, line.Errorf("expected part like {x=1,m=2,a=3,s=4}")
+		}
+		sections := line.Slice(1, len(line.Text)-1).Split(",") // remove {}
 		for _, section := range sections {
-			matches := regex2.FindStringSubmatch(section)
 			attribute := Attribute{}
-			attribute.description = matches[1]
-			attribute.value = utils.StringToInt(matches[2])
+			if err := attributeFormat.Scan(section, &attribute.description, &attribute.value); err != nil {
+				return nil, err
+			}
+			if len(attribute.description) != 1 || !strings.Contains("xmas", attribute.description) {
+				return nil, section.ErrorfAt(0, "attribute must be x, m, a or s, got %q", attribute.description)
+			}
 			part.attr = append(part.attr, attribute)
 		}
 		parts = append(parts, part)
 	}
+	return parts, nil
+}
+
+// every workflow has its own name, every target has to be a workflow, A or R,
+// the start "in" has to exist and no workflow may lead back to itself
+func checkTargets(section utils.Section, workflow []Rule) error {
+	names := map[string]bool{"A": true, "R": true}
+	for row, rule := range workflow {
+		if names[rule.name] {
+			return section.Line(row).Errorf("workflow %s is defined twice or named like a result", rule.name)
+		}
+		names[rule.name] = true
+	}
+	if !names["in"] {
+		return fmt.Errorf("no workflow in")
+	}
 
+	targets := graph.NewDirected[string]()
+	for row, rule := range workflow {
+		targets.AddNode(rule.name)
+		for _, condition := range rule.conditions {
+			if !names[condition.target] {
+				return section.Line(row).Errorf("unknown workflow %s", condition.target)
+			}
+			targets.AddEdge(rule.name, condition.target)
+		}
+	}
+	// a part in a loop is never accepted or rejected
+	if _, err := targets.TopologicalSort(); err != nil {
+		return fmt.Errorf("workflows loop: %w", err)
+	}
+	return nil
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
 // returns true if part is accepted in thge workflow
-func performWorkflow(part Part) bool {
+func (s *System) performWorkflow(part Part) bool {
 	var nextState string = "in"
 	for nextState != "A" && nextState != "R" {
-		rule := getRuleByName(nextState)
+		rule := s.getRuleByName(nextState)
 		nextState = rule.nextState(part)
 	}
 	return nextState == "A"
 }
 
-func SolvePart1() int {
+func SolvePart1(system *System) int {
 	var accepted []Part
-	for _, part := range parts {
-		if performWorkflow(part) {
+	for _, part := range system.parts {
+		if system.performWorkflow(part) {
 			accepted = append(accepted, part)
 		}
 	}
@@ -215,53 +281,52 @@ const (
 	S
 )
 
-type Range struct {
-	min int
-	max int
-}
-
-func (r Range) rangeLength() int {
-	return r.max - r.min + 1
-}
-
-var statusRanges = map[Status]Range{
-	X: {1, 4000},
-	M: {1, 4000},
-	A: {1, 4000},
-	S: {1, 4000},
+// each attribute starts with all ratings from 1 to 4000
+var statusRanges = map[Status]utils.Interval{
+	X: {Start: 1, End: 4000},
+	M: {Start: 1, End: 4000},
+	A: {Start: 1, End: 4000},
+	S: {Start: 1, End: 4000},
 }
 
 func (s Status) String() string {
 	return [...]string{"x", "m", "a", "s"}[s]
 }
 
-func cloneStatusRanges(input map[Status]Range) map[Status]Range {
-	result := make(map[Status]Range)
+func cloneStatusRanges(input map[Status]utils.Interval) map[Status]utils.Interval {
+	result := make(map[Status]utils.Interval)
 	for k, v := range input {
 		result[k] = v
 	}
 	return result
 }
 
-func combinationsOfStatusRange(sr map[Status]Range) int {
+func combinationsOfStatusRange(sr map[Status]utils.Interval) int {
 	mult := 1
 	for _, r := range sr {
-		mult *= r.rangeLength()
+		// fits, the ratings are within 1 to 4000
+		n, _ := r.Len()
+		mult *= n
 	}
 	return mult
 }
 
-func statusValid(sr map[Status]Range) bool {
+func statusValid(sr map[Status]utils.Interval) bool {
 	for _, rng := range sr {
-		if rng.min >= rng.max {
+		if rng.Empty() {
 			return false
 		}
 	}
 	return true
 }
 
-func traverseWorkflow(ruleName string, statusRanges map[Status]Range, result *[]int) {
-	fmt.Printf("Processing rule: %v,\tRanges %v,Possibilities\t%d\n", ruleName, statusRanges, combinationsOfStatusRange(statusRanges))
+func (s *System) traverseWorkflow(ruleName string, statusRanges map[Status]utils.Interval, result *[]int) {
+	//fmt.Printf("Processing rule: %v,\tRanges %v,Possibilities\t%d\n", ruleName, statusRanges, combinationsOfStatusRange(statusRanges))
+
+	// no part fits the ranges
+	if !statusValid(statusRanges) {
+		return
+	}
 
 	// accepted
 	if ruleName == "A" {
@@ -271,12 +336,12 @@ func traverseWorkflow(ruleName string, statusRanges map[Status]Range, result *[]
 	}
 
 	// rejected, discard
-	if ruleName == "R" || !statusValid(statusRanges) {
-		fmt.Println("Rejected")
+	if ruleName == "R" {
+		//fmt.Println("Rejected")
 		return
 	}
 
-	rule := getRuleByName(ruleName)
+	rule := s.getRuleByName(ruleName)
 	for _, condition := range rule.conditions {
 		if condition.name == "" {
 			// last condition has no name or attribute
@@ -285,38 +350,32 @@ func traverseWorkflow(ruleName string, statusRanges map[Status]Range, result *[]
 
 		for status, rng := range statusRanges {
 			if condition.name == status.String() {
-				tmp := rng
-				// adjust range for current condition
+				// split range by the current condition, matching part goes to target
+				var matching, rest utils.Interval
 				switch condition.op {
 				case "<":
-					rng.max = condition.value - 1
+					matching, rest = rng.SplitAt(condition.value)
 				case ">":
-					rng.min = condition.value + 1
+					rest, matching = rng.SplitAt(condition.value + 1)
 				}
-				statusRanges[status] = rng
-				traverseWorkflow(condition.target, cloneStatusRanges(statusRanges), result)
+				statusRanges[status] = matching
+				s.traverseWorkflow(condition.target, cloneStatusRanges(statusRanges), result)
 
-				// now inverse this scope change, for next condition to continue
-				rng = tmp
-				switch condition.op {
-				case "<":
-					rng.min = condition.value
-				case ">":
-					rng.max = condition.value
-				}
-				statusRanges[status] = rng
+				// the rest continues with the next condition
+				statusRanges[status] = rest
 			}
 		}
 	}
 
 	// last condition has no attribute; this is an alternative path, we already inversed condition
-	traverseWorkflow(rule.getUnconditionalState(), cloneStatusRanges(statusRanges), result)
+	s.traverseWorkflow(rule.getUnconditionalState(), cloneStatusRanges(statusRanges), result)
 }
 
 // find the range of possible inputs for all 4 attributes then multiply out the ranges
-func SolvePart2() int64 {
+func SolvePart2(system *System) int64 {
 	var result []int
-	traverseWorkflow("in", statusRanges, &result)
+	// traverseWorkflow changes the ranges it is given, keep the initial ones
+	system.traverseWorkflow("in", cloneStatusRanges(statusRanges), &result)
 	// add up length of ranges
 	var summ int64
 	for _, r := range result {
@@ -325,30 +384,26 @@ func SolvePart2() int64 {
 	return summ
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
+
+func init() {
+	puzzle.Register(19, func() puzzle.Solver { return &solver{} })
+}
 
-func main() {
-	var input []string
+type solver struct {
+	system *System
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.system, err = parseInput(input)
+	return err
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func (s *solver) Part1() any {
+	return SolvePart1(s.system)
+}
 
-	parseInput(input)
-	//printRules()
-	result1 := SolvePart1()
-	result2 := SolvePart2()
-	stopwatch.Stop()
-
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.system)
 }
@@ -0,0 +1,26 @@
+package day19
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		workflows []string
+		expected  string
+	}{
+		{"loop", []string{"in{x>1:a,R}", "a{x>1:b,A}", "b{x>1:a,R}"}, "workflows loop"},
+		{"self loop", []string{"in{x>1:in,A}"}, "workflows loop"},
+		{"defined twice", []string{"in{x>1:a,R}", "a{A}", "a{R}"}, "line 3: workflow a is defined twice"},
+		{"named like a result", []string{"in{x>1:A,R}", "A{R}"}, "line 2: workflow A is defined twice"},
+	}
+	for _, test := range tests {
+		input := append(test.workflows, "", "{x=2,m=1,a=1,s=1}")
+		_, err := parseInput(input)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: Expected an error with %q, but got %v", test.name, test.expected, err)
+		}
+	}
+}
@@ -18,30 +18,21 @@
 // Part 1: issue 1000 low pulses, result is the number of low pulses * number of high pulses
 // Part 2:
 // ---------------------------------------------------------------------------
-package main
+package day20
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/graph"
+	"github.com/cdr74/AdventOfCode2023/utils/number"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-var done = false
-var buttonCount = 0
-var lowPulseCount = 0
-var highPulseCount = 0
-
-var nodeStore = make(map[string]*BasicNode)
-
 // Pulse represents a digital signal pulse.
 type Pulse int
 
@@ -61,31 +52,23 @@ const (
 	Conjunction
 )
 
-type Node interface {
-	receivePulse(fromNode string, pulse Pulse)
-}
-
 type BasicNode struct {
-	name         string
-	destinations []string
-	nodeType     NodeType
-	isOn         bool
-	memory       map[string]Pulse
+	name     string
+	nodeType NodeType
+	isOn     bool
+	memory   map[string]Pulse
 }
 
-// just forwards Pulse to all connected nodes
-func (b *BasicNode) receivePulse(fromNode string, pulse Pulse) {
+// returns the pulse the node sends to all its destinations, if any
+func (b *BasicNode) receivePulse(fromNode string, pulse Pulse) (Pulse, bool) {
 	switch b.nodeType {
-	case Basic:
-		// just forward pulse to all destinations
-		for _, n := range b.destinations {
-			messageQueue.Push(b, n, pulse)
-		}
 	case FlipFlop:
-		b.flipFlopReceivePulse(fromNode, pulse)
+		return b.flipFlopReceivePulse(fromNode, pulse)
 	case Conjunction:
-		b.conjunctionReceivePulse(fromNode, pulse)
+		return b.conjunctionReceivePulse(fromNode, pulse)
 	}
+	// just forward pulse to all destinations
+	return pulse, true
 }
 
 // -------------------------------------------
@@ -96,24 +79,17 @@ func (b *BasicNode) receivePulse(fromNode string, pulse Pulse) {
 //
 // High Pulse
 // - any isOn -> ignore
-func (b *BasicNode) flipFlopReceivePulse(fromNode string, pulse Pulse) {
+func (b *BasicNode) flipFlopReceivePulse(fromNode string, pulse Pulse) (Pulse, bool) {
 	if pulse == High {
-		return
+		return pulse, false
 	}
-	var newPulse Pulse
 
 	if b.isOn {
 		b.isOn = false
-		newPulse = Low
-	} else {
-		b.isOn = true
-		newPulse = High
-	}
-
-	for _, n := range b.destinations {
-		// put pulse as message to all destinations in a queue
-		messageQueue.Push(b, n, newPulse)
+		return Low, true
 	}
+	b.isOn = true
+	return High, true
 }
 
 func (b *BasicNode) initializeMemory(origins []string) {
@@ -138,240 +114,238 @@ func (c *BasicNode) allMemoryHigh() bool {
 // High Pulse
 // - if memory has only high pulses -> send Low pulse
 // - otherwise send high pulse
-func (b *BasicNode) conjunctionReceivePulse(fromNode string, pulse Pulse) {
+func (b *BasicNode) conjunctionReceivePulse(fromNode string, pulse Pulse) (Pulse, bool) {
 	// update memory
 	b.memory[fromNode] = pulse
-	var newPulse Pulse
 	if b.allMemoryHigh() {
 		// send Low pulse to all destinations
-		newPulse = Low
-	} else {
-		// send High pulse to all destinations
-		newPulse = High
-	}
-
-	for _, n := range b.destinations {
-		// put pulse as message to all destinations in a queue
-		messageQueue.Push(b, n, newPulse)
+		return Low, true
 	}
+	// send High pulse to all destinations
+	return High, true
 }
 
 // ---------------------------------------------------------------------------
 
 type Message struct {
-	from  *BasicNode
+	from  string
 	to    string
 	pulse Pulse
 }
 
-type MessageQueue struct {
-	messages []Message
+// Network is the state of all nodes and the pulses on their way
+type Network struct {
+	graph          *graph.Graph[string]
+	nodes          map[string]*BasicNode
+	messages       []Message
+	buttonCount    int
+	lowPulseCount  int
+	highPulseCount int
 }
 
-func NewMessageQueue() *MessageQueue {
-	return &MessageQueue{
-		messages: make([]Message, 0),
+// Clone returns a network in the same state, each part pushes the button on
+// a network of its own. The graph does not change and is shared.
+func (n *Network) Clone() *Network {
+	clone := *n
+	clone.nodes = make(map[string]*BasicNode, len(n.nodes))
+	for name, node := range n.nodes {
+		copied := *node
+		if node.memory != nil {
+			copied.memory = make(map[string]Pulse, len(node.memory))
+			for from, pulse := range node.memory {
+				copied.memory[from] = pulse
+			}
+		}
+		clone.nodes[name] = &copied
 	}
+	clone.messages = append([]Message(nil), n.messages...)
+	return &clone
 }
 
-func (mq *MessageQueue) Push(from *BasicNode, to string, pulse Pulse) {
-	message := Message{
-		from:  from,
-		to:    to,
-		pulse: pulse,
-	}
-	if pulse == Low {
-		lowPulseCount++
-	} else {
-		highPulseCount++
+// sends the pulse of a node to all its destinations
+func (n *Network) send(from string, pulse Pulse) {
+	for _, to := range n.graph.Neighbors(from) {
+		if pulse == Low {
+			n.lowPulseCount++
+		} else {
+			n.highPulseCount++
+		}
+		n.messages = append(n.messages, Message{from: from, to: to, pulse: pulse})
 	}
-
-	mq.messages = append(mq.messages, message)
 }
 
-func (mq *MessageQueue) Pop() Message {
-	if len(mq.messages) == 0 {
-		// Returning a zero-value Message when the queue is empty
-		return Message{}
+// pushes the button, watch is called for each pulse delivered
+func (n *Network) pushButton(watch func(msg Message)) {
+	n.buttonCount++
+	n.lowPulseCount++
+	n.messages = append(n.messages, Message{from: "button", to: "broadcaster", pulse: Low})
+	for len(n.messages) > 0 {
+		msg := n.messages[0]
+		n.messages = n.messages[1:]
+		if pulse, ok := n.nodes[msg.to].receivePulse(msg.from, msg.pulse); ok {
+			n.send(msg.to, pulse)
+		}
+		if watch != nil {
+			watch(msg)
+		}
 	}
-
-	message := mq.messages[0]
-	mq.messages = mq.messages[1:]
-	return message
 }
 
-var messageQueue = NewMessageQueue()
-
 // ---------------------------------------------------------------------------
 
 func createNode(nodeName string, nodeType string) *BasicNode {
-	var node BasicNode
 	if nodeType == "%" {
 		// create flip flop node
-		node = BasicNode{name: nodeName, nodeType: FlipFlop, isOn: false}
+		return &BasicNode{name: nodeName, nodeType: FlipFlop, isOn: false}
 	} else if nodeType == "&" {
 		// create conjunction node
-		node = BasicNode{name: nodeName, nodeType: Conjunction, isOn: false}
-	} else {
-		// create basic node
-		node = BasicNode{name: nodeName, nodeType: Basic, isOn: false}
+		return &BasicNode{name: nodeName, nodeType: Conjunction, isOn: false}
 	}
-	node.destinations = make([]string, 0)
-	nodeStore[nodeName] = &node
-	return &node
+	// create basic node
+	return &BasicNode{name: nodeName, nodeType: Basic, isOn: false}
 }
 
 // processes "%lg -> zx, lx"
-func parseLine(line string) (string, []string, string) {
-	idx := strings.Index(line, "->")
-	nodeType := line[0:1]
-	nodeName := line[1 : idx-1]
+func parseLine(line utils.Line) (string, []string, string, error) {
+	idx := strings.Index(line.Text, " -> ")
+	if idx < 2 {
+		return "", nil, "", line.Errorf("expected node like %%lg -> zx, lx")
+	}
+	nodeType := line.Text[0:1]
+	nodeName := line.Text[1:idx]
 	if nodeType == "b" {
 		// keep the b of broadcaster
-		nodeName = line[0 : idx-1]
+		nodeName = line.Text[0:idx]
+		if nodeName != "broadcaster" {
+			return "", nil, "", line.ErrorfAt(0, "unknown node %s", nodeName)
+		}
+	} else if nodeType != "%" && nodeType != "&" {
+		return "", nil, "", line.ErrorfAt(0, "node type must be %% or &, got %q", nodeType)
+	}
+	destinations := strings.Split(line.Text[idx+4:], ", ")
+	for _, destination := range destinations {
+		if destination == "" {
+			return "", nil, "", line.ErrorfAt(idx+4, "empty destination")
+		}
 	}
-	destinations := strings.Split(line[idx+3:], ", ")
-	basicNode := createNode(nodeName, nodeType)
-	basicNode.destinations = append(basicNode.destinations, destinations...)
 
-	return nodeName, destinations, nodeType
+	return nodeName, destinations, nodeType, nil
 }
 
-func parseInput(input []string) {
-	var conNodes []string
-	nodeMap := make(map[string][]string)
-	for _, line := range input {
-		node, destinations, nodeType := parseLine(line)
-		// conjunction nodes need memory initialized
-		if nodeType == "&" {
-			conNodes = append(conNodes, node)
+func parseInput(input []string) (*Network, error) {
+	network := &Network{graph: graph.NewDirected[string](), nodes: make(map[string]*BasicNode)}
+	for idx, line := range input {
+		node, destinations, nodeType, err := parseLine(utils.NewLine(idx, line))
+		if err != nil {
+			return nil, err
 		}
-		nodeMap[node] = destinations
+		if _, ok := network.nodes[node]; ok {
+			return nil, utils.NewLine(idx, line).Errorf("node %s defined twice", node)
+		}
+		network.nodes[node] = createNode(node, nodeType)
+		network.graph.AddNode(node)
+		for _, dest := range destinations {
+			network.graph.AddEdge(node, dest)
+		}
+	}
+	if _, ok := network.nodes["broadcaster"]; !ok {
+		return nil, fmt.Errorf("no broadcaster node")
+	}
 
+	// destinations without a line of their own, eg output of the example or rx, just receive
+	for _, name := range network.graph.Nodes() {
+		if _, ok := network.nodes[name]; !ok {
+			network.nodes[name] = createNode(name, "")
+		}
 	}
-	rxNode := BasicNode{name: "rx", nodeType: Basic, isOn: false}
-	nodeStore["rx"] = &rxNode
 
 	// initialize memory for conjunction nodes, we need all incoming signals
-	for _, conNodeName := range conNodes {
-		conNode := nodeStore[conNodeName]
-		var destinations []string
-		for fromNodeName, _ := range nodeMap {
-			// check if conNodeName is in nodeMap[fromNodeName]
-			for _, dest := range nodeMap[fromNodeName] {
-				if dest == conNodeName {
-					destinations = append(destinations, fromNodeName)
-				}
-			}
+	for name, node := range network.nodes {
+		if node.nodeType == Conjunction {
+			node.initializeMemory(network.graph.Predecessors(name))
 		}
-		conNode.initializeMemory(destinations)
 	}
+	return network, nil
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-func SolvePart1() int {
-	broadcaster := nodeStore["broadcaster"]
+func SolvePart1(network *Network) int {
 	for x := 0; x < 1000; x++ {
-		buttonCount++
-		broadcaster.receivePulse(broadcaster.name, Low)
-		for len(messageQueue.messages) > 0 {
-			msg := messageQueue.Pop()
-			target := nodeStore[msg.to]
-			target.receivePulse(msg.from.name, msg.pulse)
-		}
+		network.pushButton(nil)
 	}
-	lowPulseCount += buttonCount
-	fmt.Printf("Low pulse count: %d, High pulse count: %d\n", lowPulseCount, highPulseCount)
-	result := lowPulseCount * highPulseCount
+	//fmt.Printf("Low pulse count: %d, High pulse count: %d\n", network.lowPulseCount, network.highPulseCount)
+	result := network.lowPulseCount * network.highPulseCount
 	return result
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-var listOfNodes = []string{"tx", "kp", "gc", "vg"}
-var countListOfNodes = make(map[string]int)
+const MAX_PUSHES int = 1000000
 
-func Gcd(a, b int) int {
-	for b != 0 {
-		a, b = b, a%b
+// rx is fed by a single conjunction, it sends a low pulse once all its inputs
+// sent a high pulse in the same push. Each input does so in a cycle of pushes,
+// they align at the least common multiple of the cycles.
+func SolvePart2(network *Network) (*big.Int, error) {
+	feeders := network.graph.Predecessors("rx")
+	if len(feeders) == 0 {
+		// eg the test data has no rx
+		return nil, fmt.Errorf("no node sends to rx")
 	}
-	return a
-}
-
-func Lcm(a, b int) int {
-	return a * b / Gcd(a, b)
-}
-
-// least common multiplier of countListOfNodes
-func lcm() {
-	var lcm int = 1
-	for _, v := range countListOfNodes {
-		lcm = Lcm(lcm, v)
+	if len(feeders) != 1 || network.nodes[feeders[0]].nodeType != Conjunction {
+		return nil, fmt.Errorf("rx must be fed by a single conjunction")
 	}
-	fmt.Printf("Least common multiplier: %d\n", lcm)
-}
-
-func processStatus(target *BasicNode, pulse Pulse) {
-	for _, node := range listOfNodes {
-		if node == target.name {
-			bqNode := nodeStore["bq"]
-			if bqNode.memory[node] == High {
-				countListOfNodes[node] = buttonCount
+	feeder := feeders[0]
+	inputs := network.graph.Predecessors(feeder)
+
+	cycles := make(map[string]int)
+	watch := func(msg Message) {
+		if msg.to == feeder && msg.pulse == High {
+			if _, ok := cycles[msg.from]; !ok {
+				cycles[msg.from] = network.buttonCount
 			}
 		}
 	}
+	for x := 0; x < MAX_PUSHES && len(cycles) < len(inputs); x++ {
+		network.pushButton(watch)
+	}
+	if len(cycles) < len(inputs) {
+		return nil, fmt.Errorf("not all inputs of %s sent a high pulse in %d pushes", feeder, MAX_PUSHES)
+	}
 
-	if len(countListOfNodes) == len(listOfNodes) {
-		lcm()
-		done = true
+	// least common multiplier of the cycles, exact even beyond int
+	var lengths []int
+	for _, v := range cycles {
+		lengths = append(lengths, v)
 	}
+	return number.BigLcmOf(lengths), nil
 }
 
-func SolvePart2() int {
-	broadcaster := nodeStore["broadcaster"]
-	for x := 0; x < 1000000 && !done; x++ {
-		buttonCount++
-		broadcaster.receivePulse(broadcaster.name, Low)
-		for len(messageQueue.messages) > 0 && !done {
-			msg := messageQueue.Pop()
-			target := nodeStore[msg.to]
-			target.receivePulse(msg.from.name, msg.pulse)
-			processStatus(target, msg.pulse)
-		}
-	}
+// -------------------------- Solver entry -----------------------------------
 
-	return 0
+func init() {
+	puzzle.Register(20, func() puzzle.Solver { return &solver{} })
 }
 
-// -------------------------- Main entry -------------------------------------
+type solver struct {
+	network *Network
+}
 
-func main() {
-	var input []string
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.network, err = parseInput(input)
+	return err
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+// each part pushes the button on a copy of the parsed network
+func (s *solver) Part1() any {
+	return SolvePart1(s.network.Clone())
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Part2() any {
+	result, err := SolvePart2(s.network.Clone())
+	if err != nil {
+		return err
 	}
-
-	parseInput(input)
-	result1 := SolvePart1()
-
-	// reset state
-	parseInput(input)
-	buttonCount = 0
-	lowPulseCount = 0
-	highPulseCount = 0
-	SolvePart2()
-	stopwatch.Stop()
-
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+	return result
 }
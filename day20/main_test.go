@@ -0,0 +1,40 @@
+package day20
+
+import (
+	"strings"
+	"testing"
+)
+
+var example = []string{
+	"broadcaster -> a",
+	"%a -> inv, con",
+	"&inv -> b",
+	"%b -> con",
+	"&con -> output",
+}
+
+// the parts push the button on copies, the parsed network stays as it is
+func TestPartsOnClones(t *testing.T) {
+	s := &solver{}
+	if err := s.Parse(example); err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < 2; n++ {
+		if result := s.Part1(); result != 11687500 {
+			t.Errorf("run %d: Expected 11687500, but got %v", n, result)
+		}
+	}
+	if s.network.buttonCount != 0 || s.network.nodes["a"].isOn {
+		t.Errorf("Expected the parsed network untouched, but got %d pushes", s.network.buttonCount)
+	}
+}
+
+func TestSolvePart2WithoutRx(t *testing.T) {
+	network, err := parseInput(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := SolvePart2(network); err == nil || !strings.Contains(err.Error(), "rx") {
+		t.Errorf("Expected an error for the missing rx, but got %v, %v", result, err)
+	}
+}
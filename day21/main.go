@@ -13,102 +13,43 @@
 // Part 1: How many tiles did we visit with 64 steps
 // Part 2:
 // ---------------------------------------------------------------------------
-package main
+package day21
 
 import (
 	"fmt"
-	"time"
 
-	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
+	"github.com/cdr74/AdventOfCode2023/utils/number"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-var garden [][]byte
-var ROWS int
-var COLS int
-
-type Position struct {
-	row int
-	col int
-}
-
-func inputToGarden(input []string) {
-	ROWS = len(input)
-	COLS = len(input[0])
-	garden = make([][]byte, ROWS)
-	for i := 0; i < ROWS; i++ {
-		garden[i] = make([]byte, COLS)
-		for j := 0; j < COLS; j++ {
-			garden[i][j] = input[i][j]
-		}
+func inputToGarden(input []string) (*grid.Grid[byte], grid.Point, error) {
+	garden, err := grid.ParseOf(input, "S.#")
+	if err != nil {
+		return nil, grid.Point{}, err
 	}
-}
-
-func printGarden(g [][]byte) {
-	for i := 0; i < ROWS; i++ {
-		for j := 0; j < COLS; j++ {
-			fmt.Printf("%c", g[i][j])
-		}
-		fmt.Printf("\n")
+	isStart := func(char byte) bool { return char == 'S' }
+	if starts := garden.Count(isStart); starts != 1 {
+		return nil, grid.Point{}, fmt.Errorf("expected a single start S, got %d", starts)
 	}
-	fmt.Printf("\n\n")
+	start, _ := garden.Find(isStart)
+	return garden, start, nil
 }
 
-func getStartPos(g [][]byte) Position {
-	var pos Position
-	for i := 0; i < ROWS; i++ {
-		for j := 0; j < COLS; j++ {
-			if g[i][j] == 'S' {
-				pos.row = i
-				pos.col = j
-				return pos
-			}
-		}
-	}
-	panic("Start not found")
-}
-
-func countVisitedTiles(g [][]byte) int {
-	var result int = 0
-	for i := 0; i < ROWS; i++ {
-		for j := 0; j < COLS; j++ {
-			if g[i][j] == 'O' {
-				result++
-			}
-		}
-	}
-	return result
-}
-
-func isValidPosition(pos Position) bool {
-	return pos.row >= 0 && pos.row < ROWS && pos.col >= 0 && pos.col < COLS
-}
-
-func nextLevelPositions(g [][]byte, queue []Position) []Position {
-	nextLevelMap := make(map[Position]struct{})
+func nextLevelPositions(garden *grid.Grid[byte], queue []grid.Point) []grid.Point {
+	nextLevelMap := make(map[grid.Point]struct{})
 
 	for x := 0; x < len(queue); x++ {
 		currPos := queue[x]
 
 		// Check if the current position is a valid tile to visit
-		if g[currPos.row][currPos.col] != '#' {
+		if garden.At(currPos) != '#' {
 
 			// Add neighboring positions to the queue
-			neighbors := []Position{
-				{currPos.row - 1, currPos.col}, // Up
-				{currPos.row + 1, currPos.col}, // Down
-				{currPos.row, currPos.col - 1}, // Left
-				{currPos.row, currPos.col + 1}, // Right
-			}
-
-			for _, neighbor := range neighbors {
-				if isValidPosition(neighbor) && g[neighbor.row][neighbor.col] != '#' {
+			for _, neighbor := range garden.Neighbors4(currPos) {
+				if garden.At(neighbor) != '#' {
 					nextLevelMap[neighbor] = struct{}{}
 				}
 			}
@@ -118,143 +59,119 @@ func nextLevelPositions(g [][]byte, queue []Position) []Position {
 
 	}
 
-	var nextLevel []Position
+	var nextLevel []grid.Point
 	for pos := range nextLevelMap {
 		nextLevel = append(nextLevel, pos)
 	}
 	return nextLevel
 }
 
-func bfsGardenWalk(g [][]byte, pos Position, depth int) [][]byte {
-	var queue []Position
+// returns the tiles we can be on after walking depth steps
+func bfsGardenWalk(garden *grid.Grid[byte], pos grid.Point, depth int) []grid.Point {
+	var queue []grid.Point
 
 	queue = append(queue, pos)
 	for len(queue) > 0 && depth > 0 {
-		queue = nextLevelPositions(g, queue)
+		queue = nextLevelPositions(garden, queue)
 		depth--
 	}
 
-	// at this level our queue holds the actual positions, mark it
-	for len(queue) > 0 {
-		currPos := queue[0]
-		queue = queue[1:]
-		g[currPos.row][currPos.col] = 'O'
-	}
-
-	return g
+	// at this level our queue holds the actual positions
+	return queue
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-var stepsPart1 int = 64 // 6 for test data, 64 for actual data
-
-func SolvePart1() int {
-	pos := getStartPos(garden)
-	g := bfsGardenWalk(garden, pos, stepsPart1)
-	//printGarden(g)
-	result := countVisitedTiles(g)
+func SolvePart1(garden *grid.Grid[byte], start grid.Point, steps int) int {
+	visited := bfsGardenWalk(garden, start, steps)
+	result := len(visited)
 	return result
 }
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-// altough passed by reference go copies the a slice if it gets expanded
-// only works on uneven multiply for start point calc
-func expandMap(g [][]byte, factor int) ([][]byte, Position) {
-	pos := getStartPos(g)
-	g[pos.row][pos.col] = '.'
-
-	// multiply length of each row by factor
-	for r := 0; r < ROWS; r++ {
-		row := g[r]
-		for f := 0; f < factor-1; f++ {
-			g[r] = append(g[r], row...)
-		}
-	}
-	// append rows by factor
-	for j := 0; j < factor-1; j++ {
-		for y := 0; y < ROWS; y++ {
-			g = append(g, g[y])
-		}
-	}
+// repeats the garden factor times in each direction, the start moves to the
+// center so only works on uneven factors
+func expandMap(garden *grid.Grid[byte], start grid.Point, factor int) (*grid.Grid[byte], grid.Point) {
+	largeMap := grid.New(garden.Rows()*factor, garden.Cols()*factor, byte('.'))
+	largeMap.Each(func(p grid.Point, _ byte) {
+		largeMap.Set(p, garden.At(grid.Point{Row: p.Row % garden.Rows(), Col: p.Col % garden.Cols()}))
+	})
 
-	// update start position
 	half := factor / 2
-	pos.row = pos.row + half*ROWS
-	pos.col = pos.col + half*COLS
-	g[pos.row][pos.col] = 'S'
-
-	ROWS = len(g)
-	COLS = len(g[0])
-
-	return g, pos
-}
-
-func copy2DSlice(src [][]byte) [][]byte {
-	dst := make([][]byte, len(src))
-	for i, row := range src {
-		newRow := make([]byte, len(row))
-		copy(newRow, row)
-		dst[i] = newRow
-	}
-	return dst
+	pos := start.Add(grid.Point{Row: half * garden.Rows(), Col: half * garden.Cols()})
+	return largeMap, pos
 }
 
-func SolvePart2() int {
-	full := ROWS
+func SolvePart2(garden *grid.Grid[byte], start grid.Point, steps int) int {
+	full := garden.Rows()
 	half := full / 2
 
-	largeMap, pos := expandMap(garden, 5)
-	largeMap_clean := copy2DSlice(largeMap)
+	largeMap, pos := expandMap(garden, start, 5)
 
-	g1 := bfsGardenWalk(copy2DSlice(largeMap_clean), pos, half)
-	t1 := countVisitedTiles(g1)
-	fmt.Printf("t1: %d\n", t1)
+	t1 := len(bfsGardenWalk(largeMap, pos, half))
+	//fmt.Printf("t1: %d\n", t1)
 
-	g2 := bfsGardenWalk(copy2DSlice(largeMap_clean), pos, half+full)
-	t2 := countVisitedTiles(g2)
-	fmt.Printf("t2: %d\n", t2)
+	t2 := len(bfsGardenWalk(largeMap, pos, half+full))
+	//fmt.Printf("t2: %d\n", t2)
 
-	g3 := bfsGardenWalk(copy2DSlice(largeMap_clean), pos, half+2*full)
-	t3 := countVisitedTiles(g3)
-	fmt.Printf("t3: %d\n", t3)
+	t3 := len(bfsGardenWalk(largeMap, pos, half+2*full))
+	//fmt.Printf("t3: %d\n", t3)
 
-	// with help from reddit - extrapolate with
-	// Lagrange's Interpolation formula
-	a := (t3 + t1 - 2*t2) / 2
-	b := t2 - t1 - a
-	c := t1
-	n := 26501365 / full
-	result := a*n*n + b*n + c
+	// with help from reddit - the reachable plots grow quadratic in the
+	// number of gardens walked through, extrapolate the fit to the steps
+	p, err := number.FitSequence([]int{t1, t2, t3}, 2)
+	if err != nil {
+		panic("SolvePart2() - " + err.Error())
+	}
+	n := steps / full
+	result, err := number.Int[int](number.AtInt(p, n))
+	if err != nil {
+		panic("SolvePart2() - plots are not a whole number")
+	}
 
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func main() {
-	var input []string
+func init() {
+	puzzle.Register(21, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	garden     *grid.Grid[byte]
+	start      grid.Point
+	steps      int
+	stepsPart2 int
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Settings() []puzzle.Setting {
+	return []puzzle.Setting{
+		{Name: "steps", Usage: "steps to walk in part 1", Test: "6", Actual: "64"},
+		{Name: "steps_part2", Usage: "steps to walk in part 2", Test: "26501365", Actual: "26501365"},
 	}
+}
 
-	inputToGarden(input)
-	result1 := SolvePart1()
+func (s *solver) Configure(settings puzzle.Settings) error {
+	var err error
+	if s.steps, err = settings.Int("steps"); err != nil {
+		return err
+	}
+	s.stepsPart2, err = settings.Int("steps_part2")
+	return err
+}
 
-	inputToGarden(input)
-	result2 := SolvePart2()
-	stopwatch.Stop()
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.garden, s.start, err = inputToGarden(input)
+	return err
+}
+
+func (s *solver) Part1() any {
+	return SolvePart1(s.garden, s.start, s.steps)
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.garden, s.start, s.stepsPart2)
 }
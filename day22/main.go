@@ -30,263 +30,183 @@
 //	other bricks falls down
 //
 // ---------------------------------------------------------------------------
-package main
+package day22
 
 import (
-	"fmt"
 	"sort"
-	"strings"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
-	"github.com/pkg/profile"
-	"github.com/samber/lo"
+	"github.com/cdr74/AdventOfCode2023/utils/geometry"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-var brickMapByLowestZ map[int][]*Brick
-var brickCount int = 0
-
-func deepCopyBrickMapByLowestZ(org map[int][]*Brick) map[int][]*Brick {
-	newMap := make(map[int][]*Brick)
-	for lowestZ, bricks := range org {
-		newBricks := make([]*Brick, len(bricks))
-		for i, brick := range bricks {
-			newBricks[i] = brick.Copy()
-		}
-		newMap[lowestZ] = newBricks
-	}
-	return newMap
-}
-
-func removeBrickFromBrickMap(ID int) {
-	for i := range brickMapByLowestZ {
-		brickMapByLowestZ[i] = lo.Filter(brickMapByLowestZ[i], func(b *Brick, i int) bool {
-			return b.ID != ID
-		})
-	}
-}
-
-// Brick represents a 3D brick
+// Brick represents a 3D brick, the box of cubes it is made of
 type Brick struct {
-	ID                     int
-	StartX, StartY, StartZ int
-	EndX, EndY, EndZ       int
-}
-
-// Intersects checks if two bricks intersect
-func (b *Brick) Intersects(other *Brick) bool {
-	return b.StartX <= other.EndX &&
-		b.EndX >= other.StartX &&
-		b.StartY <= other.EndY &&
-		b.EndY >= other.StartY &&
-		b.StartZ <= other.EndZ &&
-		b.EndZ >= other.StartZ
+	ID  int
+	Box geometry.Box3
 }
 
-func (b *Brick) intersectionFree() bool {
-	otherBricks := brickSliceFromMap()
-	for _, otherBrick := range otherBricks {
-		if b.ID != otherBrick.ID && b.Intersects(otherBrick) {
-			return false
-		}
-	}
-	return true
+// Overlaps checks if two bricks share a column when seen from above
+func (b *Brick) Overlaps(other *Brick) bool {
+	return b.Box.XY().Overlaps(other.Box.XY())
 }
 
-func (b *Brick) Copy() *Brick {
-	return &Brick{
-		ID:     b.ID,
-		StartX: b.StartX,
-		StartY: b.StartY,
-		StartZ: b.StartZ,
-		EndX:   b.EndX,
-		EndY:   b.EndY,
-		EndZ:   b.EndZ,
-	}
-}
+// Input format: 1,0,1~1,2,1
+var brickFormat = utils.MustLineFormat("%d,%d,%d~%d,%d,%d")
 
 // CreateBricks creates a list of bricks
-func CreateBricks(input []string) {
-	brickMapByLowestZ = make(map[int][]*Brick)
-
-	for _, line := range input {
-		coordString := strings.Replace(line, "~", ",", -1)
-		points := strings.Split(coordString, ",")
-		if len(points) != 6 {
-			panic("CreateBricks() - Invalid number of points in line")
+func CreateBricks(input []string) ([]*Brick, error) {
+	var bricks []*Brick
+	for idx, line := range input {
+		context := utils.NewLine(idx, line)
+		brick := Brick{ID: idx}
+		start, end := &brick.Box.Min, &brick.Box.Max
+		err := brickFormat.Scan(context, &start.X, &start.Y, &start.Z, &end.X, &end.Y, &end.Z)
+		if err != nil {
+			return nil, err
 		}
-
-		brick := Brick{
-			ID:     brickCount,
-			StartX: utils.StringToInt(points[0]),
-			StartY: utils.StringToInt(points[1]),
-			StartZ: utils.StringToInt(points[2]),
-			EndX:   utils.StringToInt(points[3]),
-			EndY:   utils.StringToInt(points[4]),
-			EndZ:   utils.StringToInt(points[5]),
+		if brick.Box.Empty() {
+			return nil, context.Errorf("start of brick must not be after its end")
 		}
-		brickCount++
-
-		if brickMapByLowestZ[brick.StartZ] == nil {
-			brickMapByLowestZ[brick.StartZ] = make([]*Brick, 0)
+		if brick.Box.Min.Z < 1 {
+			return nil, context.Errorf("brick must be above the ground at z=0")
 		}
-		brickMapByLowestZ[brick.StartZ] = append(brickMapByLowestZ[brick.StartZ], &brick)
+		bricks = append(bricks, &brick)
 	}
+	return bricks, nil
 }
 
-func brickSliceFromMap() []*Brick {
-	bricks := make([]*Brick, 0)
-	for i := range brickMapByLowestZ {
-		lo.ForEach(brickMapByLowestZ[i], func(b *Brick, _ int) {
-			bricks = append(bricks, b.Copy())
-		})
-	}
-	return bricks
+// Tower is the settled pile of bricks, supports lists the bricks resting on
+// a brick and supportedBy the ones a brick rests on
+type Tower struct {
+	bricks      []*Brick
+	supports    [][]int
+	supportedBy [][]int
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-func moveBrickDownByOne(brick *Brick) bool {
-	if brick.StartZ <= 1 {
-		return false
+// lets the bricks fall down until they touch ground or a brick below them.
+// Bricks are dropped from the lowest up, so everything below a brick has
+// settled already when it falls.
+func letBricksFallDown(input []*Brick) *Tower {
+	bricks := make([]*Brick, len(input))
+	for idx, brick := range input {
+		settled := *brick
+		bricks[idx] = &settled
 	}
-	// update brick coordinates
-	brick.EndZ--
-	brick.StartZ--
+	sort.Slice(bricks, func(i, j int) bool { return bricks[i].Box.Min.Z < bricks[j].Box.Min.Z })
 
-	// check if brick intersects with any brick
-	movePossible := brick.intersectionFree()
-
-	if movePossible {
-		// move brick to new level in brickMapByLowestZ
-		if brickMapByLowestZ[brick.StartZ] == nil {
-			brickMapByLowestZ[brick.StartZ] = make([]*Brick, 0)
+	tower := &Tower{
+		bricks:      bricks,
+		supports:    make([][]int, len(bricks)),
+		supportedBy: make([][]int, len(bricks)),
+	}
+	for idx, brick := range bricks {
+		// the brick stops on the highest bricks below it
+		floor := 0
+		var below []int
+		for other := 0; other < idx; other++ {
+			if !brick.Overlaps(bricks[other]) {
+				continue
+			}
+			if top := bricks[other].Box.Max.Z; top > floor {
+				floor = top
+				below = []int{other}
+			} else if top == floor {
+				below = append(below, other)
+			}
+		}
+		brick.Box = brick.Box.Shift(geometry.Point3{Z: floor + 1 - brick.Box.Min.Z})
+		for _, other := range below {
+			tower.supports[other] = append(tower.supports[other], idx)
+			tower.supportedBy[idx] = append(tower.supportedBy[idx], other)
 		}
-		// add brick to new level
-		brickMapByLowestZ[brick.StartZ] = append(brickMapByLowestZ[brick.StartZ], brick)
-		// remove from old level in brickMapByLowestZ
-		oldZ := brick.StartZ + 1
-		brickMapByLowestZ[oldZ] = lo.Filter(brickMapByLowestZ[oldZ], func(b *Brick, i int) bool {
-			return *b != *(brick)
-		})
-	} else {
-		// undo move
-		brick.EndZ++
-		brick.StartZ++
-		return false
 	}
+	return tower
+}
 
+// a brick can be disintegrated if every brick on it rests on another one too
+func (t *Tower) canDisintegrate(idx int) bool {
+	for _, above := range t.supports[idx] {
+		if len(t.supportedBy[above]) == 1 {
+			return false
+		}
+	}
 	return true
 }
 
-func moveBricksAtZDownByOne(z int, fallenBricks []bool) int {
-	moveCnt := 0
-
-	for _, brick := range brickMapByLowestZ[z] {
-		moving := true
-		for moving {
-			if moveBrickDownByOne(brick) {
-				fallenBricks[brick.ID] = true
-				moveCnt++
-			} else {
-				moving = false
-			}
+func SolvePart1(tower *Tower) int {
+	count := 0
+	for idx := range tower.bricks {
+		if tower.canDisintegrate(idx) {
+			count++
 		}
 	}
-
-	return moveCnt
+	return count
 }
 
-func letBricksFallDown() int {
-	fallenBricks := make([]bool, brickCount)
-
-	// let bricks fall down until they touch ground or a brick below them
-	done := false
-	for !done {
-		done = true
-		zKeys := lo.Keys(brickMapByLowestZ)
-		sort.Ints(zKeys)
-
-		for _, z := range zKeys {
-			// try to move each brick down if not at 1 (lowest)
-			if z <= 1 {
-				continue
-			}
-
-			moved := moveBricksAtZDownByOne(z, fallenBricks)
-			if moved > 0 {
-				done = false
+// -------------------------- Puzzle part 2 ----------------------------------
+
+// counts the bricks that fall when brick idx is removed, a brick falls once
+// all bricks it rests on have fallen. A brick only rests on bricks settled
+// before it, so they are all checked already.
+func (t *Tower) countFalling(idx int) int {
+	fallen := map[int]bool{idx: true}
+	count := 0
+	for above := idx + 1; above < len(t.bricks); above++ {
+		if len(t.supportedBy[above]) == 0 {
+			continue
+		}
+		falls := true
+		for _, below := range t.supportedBy[above] {
+			if !fallen[below] {
+				falls = false
+				break
 			}
 		}
+		if falls {
+			fallen[above] = true
+			count++
+		}
 	}
+	return count
+}
 
+// sum up for all bricks that are structurally relevant, how many other bricks fall down
+func SolvePart2(tower *Tower) int {
 	total := 0
-	for f := range fallenBricks {
-		if fallenBricks[f] {
-			total++
-		}
+	for idx := range tower.bricks {
+		total += tower.countFalling(idx)
 	}
 	return total
 }
 
-func countStructuralRelevantBricks() (int, int) {
-	totalMovedCount := 0
-	structuralRelevantBricks := 0
-
-	copy_brickMapByLowestZ := deepCopyBrickMapByLowestZ(brickMapByLowestZ)
-	loop_bricks := brickSliceFromMap()
-
-	for _, brick := range loop_bricks {
-		// remove this brick, see if anything would move
-		removeBrickFromBrickMap(brick.ID)
-
-		bricksMovedCount := letBricksFallDown()
-		if bricksMovedCount > 0 {
-			totalMovedCount += bricksMovedCount
-			structuralRelevantBricks++
-			fmt.Printf("%v --v-- %d\n", brick, bricksMovedCount)
-		}
-
-		// restore state of global brick data structure
-		brickMapByLowestZ = deepCopyBrickMapByLowestZ(copy_brickMapByLowestZ)
-	}
+// -------------------------- Solver entry -----------------------------------
 
-	return len(loop_bricks) - structuralRelevantBricks, totalMovedCount
+func init() {
+	puzzle.Register(22, func() puzzle.Solver { return &solver{} })
 }
 
-func SolvePart1_2() (int, int) {
-	letBricksFallDown()
-	// find all bricks that are needed to support other bricks
-	b, t := countStructuralRelevantBricks()
-	return b, t
+type solver struct {
+	tower *Tower
 }
 
-// -------------------------- Main entry -------------------------------------
-
-func main() {
-	// Enable CPU profiling
-	defer profile.Start(profile.ProfilePath(".")).Stop()
-
-	var input []string
-
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+func (s *solver) Parse(input []string) error {
+	bricks, err := CreateBricks(input)
+	if err != nil {
+		return err
 	}
+	s.tower = letBricksFallDown(bricks)
+	return nil
+}
 
-	CreateBricks(input)
-	result1, result2 := SolvePart1_2()
-
-	// ---------------------- Print results ----------------------------------
+func (s *solver) Part1() any {
+	return SolvePart1(s.tower)
+}
 
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
+func (s *solver) Part2() any {
+	return SolvePart2(s.tower)
 }
@@ -26,196 +26,185 @@
 //
 // Part 2:
 // ---------------------------------------------------------------------------
-package main
+package day23
 
 import (
+	"bytes"
 	"fmt"
-	"time"
 
-	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/utils/graph"
+	"github.com/cdr74/AdventOfCode2023/utils/grid"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-func getNodeID(i, j int) string {
-	return fmt.Sprintf("%d_%d", i, j)
-}
-
-// Node represents a node in the graph
-type Node struct {
-	ID        string
-	Neighbors []*Node
+// the only way a slope can be passed
+var slopes = map[byte]grid.Point{
+	'^': grid.North,
+	'>': grid.East,
+	'v': grid.South,
+	'<': grid.West,
 }
 
-// AddNeighbor adds a neighbor to the node
-func (n *Node) AddNeighbor(neighbor *Node) {
-	n.Neighbors = append(n.Neighbors, neighbor)
-}
-
-// Graph represents the graph
-type Graph struct {
-	Nodes map[string]*Node
+// start is the only path tile in the top row, end the only one in the bottom row
+func findPathTile(trails *grid.Grid[byte], row int) (*grid.Point, error) {
+	col := bytes.IndexByte(trails.Row(row), '.')
+	if col < 0 {
+		return nil, fmt.Errorf("no path tile in row %d", row+1)
+	}
+	return &grid.Point{Row: row, Col: col}, nil
 }
 
-// AddNode adds a node to the graph
-func (g *Graph) AddNode(node *Node) {
-	g.Nodes[node.ID] = node
+// the map is a rectangle of paths, forest and slopes
+func inputToTrails(input []string) (*grid.Grid[byte], error) {
+	return grid.ParseOf(input, ".#^>v<")
 }
 
-func (g *Graph) print() {
-	for _, node := range g.Nodes {
-		fmt.Printf("Node: %s\t -> ", node.ID)
-		for _, neighbor := range node.Neighbors {
-			fmt.Printf("%s ", neighbor.ID)
+// CreateGraph creates a directed graph of the path tiles, slopes can only be
+// passed in their direction
+func CreateGraph(trails *grid.Grid[byte]) *graph.Graph[grid.Point] {
+	g := graph.NewDirected[grid.Point]()
+	isPath := func(c byte) bool { return c != '#' }
+	for _, pos := range trails.FindAll(isPath) {
+		g.AddNode(pos)
+		directions := []grid.Point{grid.South, grid.North, grid.East, grid.West}
+		if slope, ok := slopes[trails.At(pos)]; ok {
+			directions = []grid.Point{slope}
 		}
-		fmt.Println()
-	}
-}
-
-func connectNeighbors(graph *Graph, neighborMap *map[string][]string) {
-	for nodeID, neighbors := range *neighborMap {
-		for _, neighborID := range neighbors {
-			if neighbor, ok := graph.Nodes[neighborID]; ok {
-				graph.Nodes[nodeID].AddNeighbor(neighbor)
+		for _, dir := range directions {
+			if next, ok := trails.Get(pos.Add(dir)); ok && isPath(next) {
+				g.AddEdge(pos, pos.Add(dir))
 			}
 		}
 	}
+	return g
 }
 
-// CreateGraph creates a graph from a 2x2 char array
-func CreateGraph(grid []string) *Graph {
-	neighborMap := make(map[string][]string)
-	graph := &Graph{Nodes: make(map[string]*Node)}
-	for i := 0; i < len(grid); i++ {
-		for j, c := range grid[i] {
-			if c != '#' {
-				nodeID := getNodeID(i, j)
-				node := &Node{ID: nodeID}
-				graph.AddNode(node)
-
-				switch c {
-				case '^':
-					neighborMap[nodeID] = []string{getNodeID(i-1, j)}
-				case '>':
-					neighborMap[nodeID] = []string{getNodeID(i, j+1)}
-				case 'v':
-					neighborMap[nodeID] = []string{getNodeID(i+1, j)}
-				case '<':
-					neighborMap[nodeID] = []string{getNodeID(i, j-1)}
-				default:
-					neighborMap[nodeID] = []string{getNodeID(i+1, j), getNodeID(i-1, j), getNodeID(i, j+1), getNodeID(i, j-1)}
-				}
+// CreateGraph_Part2 ignores the slopes, so each step can be taken both ways
+func CreateGraph_Part2(trails *grid.Grid[byte]) *graph.Graph[grid.Point] {
+	g := graph.NewUndirected[grid.Point]()
+	isPath := func(c byte) bool { return c != '#' }
+	for _, pos := range trails.FindAll(isPath) {
+		g.AddNode(pos)
+		for _, dir := range []grid.Point{grid.South, grid.East} {
+			if next, ok := trails.Get(pos.Add(dir)); ok && isPath(next) {
+				g.AddEdge(pos, pos.Add(dir))
 			}
 		}
 	}
-	connectNeighbors(graph, &neighborMap)
-
-	return graph
+	return g
 }
 
-func CreateGraph_Part2(grid []string) *Graph {
-	neighborMap := make(map[string][]string)
-	graph := &Graph{Nodes: make(map[string]*Node)}
-	for i := 0; i < len(grid); i++ {
-		for j, c := range grid[i] {
-			if c != '#' {
-				nodeID := getNodeID(i, j)
-				node := &Node{ID: nodeID}
-				graph.AddNode(node)
-				neighborMap[nodeID] = []string{getNodeID(i+1, j), getNodeID(i-1, j), getNodeID(i, j+1), getNodeID(i, j-1)}
-			}
-		}
+// Longest path is a NP problem, best bet is depth first search with
+// backtracking. The trails are long corridors between few junctions, they
+// are compressed into single edges first so there are only few paths to try.
+// It is an error if end can not be reached.
+func longestHike(g *graph.Graph[grid.Point], start grid.Point, end grid.Point) (int, error) {
+	isStartOrEnd := func(pos grid.Point) bool { return pos == start || pos == end }
+	junctions := g.Compress(isStartOrEnd)
+	//junctions.WriteDOT(os.Stdout, "trails")
+	steps, _, ok := junctions.LongestPath(start, end)
+	if !ok {
+		return 0, fmt.Errorf("no path from %v to %v", start, end)
 	}
-	connectNeighbors(graph, &neighborMap)
-
-	return graph
+	return steps, nil
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-// no optimization done, given this is a np problem... not ideal
-// memory of visited nodes could help a lot
-func dfs(graph *Graph, node *Node, end *Node, visited *map[string]bool, path *[]string, maxLength *int) {
-	(*visited)[node.ID] = true
-	*path = append(*path, node.ID)
-
-	if node == end {
-		if len(*path) > *maxLength {
-			*maxLength = len(*path)
-			//fmt.Printf("Path: %v - steps %d\n", *path, *maxLength-1)
-		}
-	}
+func SolvePart1(g *graph.Graph[grid.Point], start grid.Point, end grid.Point) (int, error) {
+	return longestHike(g, start, end)
+}
 
-	for _, neighbor := range node.Neighbors {
-		if !(*visited)[neighbor.ID] {
-			dfs(graph, neighbor, end, visited, path, maxLength)
-		}
-	}
+// -------------------------- Puzzle part 2 ----------------------------------
 
-	// backtracking
-	*path = (*path)[:len(*path)-1]
-	(*visited)[node.ID] = false
+func SolvePart2(g *graph.Graph[grid.Point], start grid.Point, end grid.Point) (int, error) {
+	return longestHike(g, start, end)
 }
 
-// As longest path is a np hard problem, best bet is depth first search
-// with backtracking.
-func SolvePart1(g *Graph) int {
-	startNodeID := getNodeID(0, 1)
-	endNodeID := getNodeID(140, 139)
-	if runTest {
-		endNodeID = getNodeID(22, 21)
-	}
-	path := []string{}
-	maxLength := 0
-	dfs(g, g.Nodes[startNodeID], g.Nodes[endNodeID], &map[string]bool{}, &path, &maxLength)
-	return maxLength - 1
+// -------------------------- Solver entry -----------------------------------
+
+func init() {
+	puzzle.Register(23, func() puzzle.Solver { return &solver{} })
 }
 
-// -------------------------- Puzzle part 2 ----------------------------------
+type solver struct {
+	graph1      *graph.Graph[grid.Point]
+	graph2      *graph.Graph[grid.Point]
+	startNodeID *grid.Point
+	endNodeID   *grid.Point
+}
 
-func SolvePart2(g *Graph) int {
-	startNodeID := getNodeID(0, 1)
-	endNodeID := getNodeID(140, 139)
-	if runTest {
-		endNodeID = getNodeID(22, 21)
+func (s *solver) Settings() []puzzle.Setting {
+	return []puzzle.Setting{
+		{Name: "start", Usage: "row,col of the start tile, auto uses the path tile in the top row", Test: "auto", Actual: "auto"},
+		{Name: "end", Usage: "row,col of the end tile, auto uses the path tile in the bottom row", Test: "auto", Actual: "auto"},
 	}
-	path := []string{}
-	maxLength := 0
-	dfs(g, g.Nodes[startNodeID], g.Nodes[endNodeID], &map[string]bool{}, &path, &maxLength)
-	return maxLength - 1
 }
 
-// -------------------------- Main entry -------------------------------------
-
-func main() {
-	var input []string
-
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func (s *solver) Configure(settings puzzle.Settings) error {
+	var err error
+	if s.startNodeID, err = settingToPoint(settings["start"]); err != nil {
+		return fmt.Errorf("setting start: %w", err)
+	}
+	if s.endNodeID, err = settingToPoint(settings["end"]); err != nil {
+		return fmt.Errorf("setting end: %w", err)
+	}
+	return nil
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
+// turns "row,col" into a position, auto is kept as nil
+func settingToPoint(value string) (*grid.Point, error) {
+	if value == "auto" {
+		return nil, nil
+	}
+	var pos grid.Point
+	if _, err := fmt.Sscanf(value, "%d,%d", &pos.Row, &pos.Col); err != nil {
+		return nil, err
 	}
+	return &pos, nil
+}
 
-	graph1 := CreateGraph(input)
-	graph2 := CreateGraph_Part2(input)
+func (s *solver) Parse(input []string) error {
+	trails, err := inputToTrails(input)
+	if err != nil {
+		return err
+	}
+	// only auto settings look for the path tiles
+	if s.startNodeID == nil {
+		if s.startNodeID, err = findPathTile(trails, 0); err != nil {
+			return err
+		}
+	}
+	if s.endNodeID == nil {
+		if s.endNodeID, err = findPathTile(trails, trails.Rows()-1); err != nil {
+			return err
+		}
+	}
+	s.graph1 = CreateGraph(trails)
+	s.graph2 = CreateGraph_Part2(trails)
 
-	result1 := SolvePart1(graph1)
-	result2 := SolvePart1(graph2)
+	for _, nodeID := range []grid.Point{*s.startNodeID, *s.endNodeID} {
+		if !s.graph1.HasNode(nodeID) {
+			return fmt.Errorf("tile %v is not a path", nodeID)
+		}
+	}
+	return nil
+}
 
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	result, err := SolvePart1(s.graph1, *s.startNodeID, *s.endNodeID)
+	if err != nil {
+		return err
+	}
+	return result
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	result, err := SolvePart2(s.graph2, *s.startNodeID, *s.endNodeID)
+	if err != nil {
+		return err
+	}
+	return result
 }
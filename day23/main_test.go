@@ -0,0 +1,39 @@
+package day23
+
+import (
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+)
+
+// the hike goes from west to east, top and bottom row have no path tile
+func TestConfiguredStartAndEnd(t *testing.T) {
+	s := &solver{}
+	if err := s.Configure(puzzle.Settings{"start": "1,0", "end": "1,4"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse([]string{"#####", "..>..", "#####"}); err != nil {
+		t.Fatal(err)
+	}
+	if result := s.Part1(); result != 4 {
+		t.Errorf("Expected 4, but got %v", result)
+	}
+
+	if err := (&solver{}).Parse([]string{"#####", "..>..", "#####"}); err == nil {
+		t.Errorf("Expected an error for auto without a path tile in the top row")
+	}
+}
+
+// the slope only allows part 1 to go west, part 2 ignores it
+func TestNoPath(t *testing.T) {
+	s := &solver{}
+	if err := s.Parse([]string{"#.###", "#.<.#", "###.#"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Part1().(error); !ok {
+		t.Errorf("Expected an error for part 1, but got %v", s.Part1())
+	}
+	if result := s.Part2(); result != 4 {
+		t.Errorf("Expected 4, but got %v", result)
+	}
+}
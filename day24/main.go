@@ -1,6 +1,6 @@
 // ---------------------------------------------------------------------------
-// Golang solution for Advent of Code 2023 day ..
-// https://adventofcode.com/2023/day/..
+// Golang solution for Advent of Code 2023 day 24
+// https://adventofcode.com/2023/day/24
 //
 // This was created using copilot to assist me in learning Go.
 //
@@ -12,147 +12,203 @@
 //		Data format:
 //	    19, 13, 30 @ -2,  1, -2 (x, y, z, @, dx, dy, dz)
 //
-// Part 1:
-// Part 2:
+// Part 1: count the crossings of the paths inside the test area
+// Part 2: throw a rock that hits every hailstone, sum of its start position
 // ---------------------------------------------------------------------------
-package main
+package day24
 
 import (
 	"fmt"
-	"math"
-	"strings"
-	"time"
 
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/geometry"
+	"github.com/cdr74/AdventOfCode2023/utils/linalg"
+	"github.com/cdr74/AdventOfCode2023/utils/number"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = false
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
-type Point struct {
-	X float64
-	Y float64
+// Hailstone moves from its position by its velocity each nanosecond
+type Hailstone struct {
+	Position geometry.Point3
+	Velocity geometry.Point3
 }
 
-type Vector2D struct {
-	X  float64
-	Y  float64
-	DX float64
-	DY float64
+// XY is the path seen from above, part 1 ignores z
+func (h Hailstone) XY() geometry.Ray2 {
+	return geometry.Ray2{Origin: h.Position.XY(), Direction: h.Velocity.XY()}
 }
 
-func stringToVector2D(s string) Vector2D {
-	var x, y, z, dx, dy, dz float64
+var vectorFormat = utils.MustLineFormat("%d, %d, %d @ %d, %d, %d")
 
-	_, err := fmt.Sscanf(strings.ReplaceAll(s, ",", ""), "%f %f %f @ %f %f %f", &x, &y, &z, &dx, &dy, &dz)
-	if err != nil {
-		panic("Error parsing vector data")
+func stringToHailstone(line utils.Line) (Hailstone, error) {
+	var x, y, z, dx, dy, dz int
+
+	if err := vectorFormat.Scan(line, &x, &y, &z, &dx, &dy, &dz); err != nil {
+		return Hailstone{}, err
 	}
 
-	v := Vector2D{X: x, Y: y, DX: dx, DY: dy}
-	return v
+	h := Hailstone{Position: geometry.Point3{X: x, Y: y, Z: z}, Velocity: geometry.Point3{X: dx, Y: dy, Z: dz}}
+	return h, nil
 }
 
-func inputToVectorList(input []string) []Vector2D {
-	var vectors []Vector2D
+func inputToHailstones(input []string) ([]Hailstone, error) {
+	var hailstones []Hailstone
 
-	for _, s := range input {
-		v := stringToVector2D(s)
-		vectors = append(vectors, v)
+	for idx, s := range input {
+		h, err := stringToHailstone(utils.NewLine(idx, s))
+		if err != nil {
+			return nil, err
+		}
+		hailstones = append(hailstones, h)
 	}
-	return vectors
+	return hailstones, nil
 }
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-/*
- *  Over n ticks of time t the vector will move to (x,y)
- *  (x,y) = (Vector2D.DX, Vector2D.DY) * t + (Vector2D.X , Vector2D.Y)
- *
- *  to find the intersection set both equal same (x, y)  and solve for t1, t2
- */
-func intersectionPoint(v1 Vector2D, v2 Vector2D) Point {
-	var p = Point{X: 0, Y: 0}
+// counts intersections inside the test area, x and y both within minDistance..maxDistance.
+// Paths only count if they cross in the future of both hailstones, parallel
+// ones never cross.
+func SolvePart1(hailstones []Hailstone, minDistance int, maxDistance int) int {
+	count := 0
+	low, high := number.RatOf(minDistance), number.RatOf(maxDistance)
+	inside := func(r number.Rat) bool { return r.Cmp(low) >= 0 && r.Cmp(high) <= 0 }
 
-	t1 := (v2.Y - v1.Y + (v2.DY/v2.DX)*v1.X - (v2.DY/v2.DX)*v2.X) / (v1.DY - (v2.DY/v2.DX)*v1.DX)
-	t2 := (v1.X + v1.DX*t1 - v2.X) / v2.DX
+	for x := 0; x < len(hailstones)-1; x++ {
+		h1 := hailstones[x]
+		for y := x + 1; y < len(hailstones); y++ {
+			h2 := hailstones[y]
 
-	if math.IsInf(t1, 0) || math.IsNaN(t1) || t1 < float64(0) {
-		return p
-	}
-	if math.IsInf(t2, 0) || math.IsNaN(t2) || t2 < float64(0) {
-		return p
+			crossing := h1.XY().Intersect(h2.XY())
+			//fmt.Printf("h1: %v, h2: %v, crossing: %+v\n", h1, h2, crossing)
+			if crossing.Kind == geometry.Crossing && inside(crossing.Point.X) && inside(crossing.Point.Y) {
+				count++
+			}
+		}
 	}
-
-	p.X = v1.DX*t1 + v1.X
-	p.Y = v1.DY*t1 + v1.Y
-
-	fmt.Printf("OK: v1: %v, v2: %v, t1: %f, p2: %v\n", v1, v2, t1, p)
-	return p
+	return count
 }
 
-func SolvePart1(vectors []Vector2D) int {
-	minDistance := 7
-	maxDistance := 27
+// -------------------------- Puzzle part 2 ----------------------------------
 
-	if !runTest {
-		minDistance = 200000000000000
-		maxDistance = 400000000000000
+// cross product in exact numbers, positions times velocities get large
+func cross(a, b geometry.Point3) [3]number.Rat {
+	ax, ay, az := number.RatOf(a.X), number.RatOf(a.Y), number.RatOf(a.Z)
+	bx, by, bz := number.RatOf(b.X), number.RatOf(b.Y), number.RatOf(b.Z)
+	return [3]number.Rat{
+		ay.Mul(bz).Sub(az.Mul(by)),
+		az.Mul(bx).Sub(ax.Mul(bz)),
+		ax.Mul(by).Sub(ay.Mul(bx)),
 	}
+}
 
-	count := 0
-
-	for x := 0; x < len(vectors)-1; x++ {
-		v1 := vectors[x]
-		for y := x + 1; y < len(vectors); y++ {
-			v2 := vectors[y]
-
-			p := intersectionPoint(v1, v2)
+// adds the 3 equations of hailstones i and j to the system, with
+// w = vj - vi, d = pj - pi and the unknowns P and V of the rock
+//
+//	P x w + d x V = pj x vj - pi x vi
+func addEquations(rows [][]number.Rat, rhs []number.Rat, hi Hailstone, hj Hailstone) ([][]number.Rat, []number.Rat) {
+	w := hj.Velocity.Sub(hi.Velocity)
+	d := hj.Position.Sub(hi.Position)
+	r := func(values ...int) []number.Rat {
+		row := make([]number.Rat, len(values))
+		for idx, value := range values {
+			row[idx] = number.RatOf(value)
+		}
+		return row
+	}
+	// columns are Px, Py, Pz, Vx, Vy, Vz
+	rows = append(rows,
+		r(0, w.Z, -w.Y, 0, -d.Z, d.Y),
+		r(-w.Z, 0, w.X, d.Z, 0, -d.X),
+		r(w.Y, -w.X, 0, -d.Y, d.X, 0),
+	)
+	cj, ci := cross(hj.Position, hj.Velocity), cross(hi.Position, hi.Velocity)
+	for axis := 0; axis < 3; axis++ {
+		rhs = append(rhs, cj[axis].Sub(ci[axis]))
+	}
+	return rows, rhs
+}
 
-			if p.X >= float64(minDistance) && p.X <= float64(maxDistance) &&
-				p.Y >= float64(minDistance) && p.Y <= float64(maxDistance) {
-				count++
-			}
+/*
+ *  The rock starts at P with velocity V and hits each hailstone i at some
+ *  time ti, P + ti*V = pi + ti*vi. So P - pi and V - vi are parallel
+ *
+ *    (P - pi) x (V - vi) = 0
+ *    P x V - P x vi - pi x V + pi x vi = 0
+ *
+ *  P x V is the same for all hailstones, subtracting the equations of two
+ *  hailstones leaves 3 linear equations in P and V. Two pairs give 6
+ *  equations for the 6 unknowns.
+ *
+ *  It is an error if no three hailstones give a single solution or the rock
+ *  does not start at a whole number position.
+ */
+func SolvePart2(hailstones []Hailstone) (int, error) {
+	for first := 0; first+2 < len(hailstones); first++ {
+		var rows [][]number.Rat
+		var rhs []number.Rat
+		rows, rhs = addEquations(rows, rhs, hailstones[first], hailstones[first+1])
+		rows, rhs = addEquations(rows, rhs, hailstones[first], hailstones[first+2])
+
+		x, err := linalg.OfRats(rows).Solve(rhs)
+		if err != nil {
+			// eg parallel hailstones, try the next ones
+			continue
+		}
+		//fmt.Printf("Rock at %v, %v, %v with velocity %v, %v, %v\n", x[0], x[1], x[2], x[3], x[4], x[5])
+		sum, err := number.Int[int](x[0].Add(x[1]).Add(x[2]))
+		if err != nil {
+			return 0, fmt.Errorf("rock at %v, %v, %v: %w", x[0], x[1], x[2], err)
 		}
+		return sum, nil
 	}
-	return count
+	return 0, fmt.Errorf("no three of %d hailstones determine the rock", len(hailstones))
 }
 
-// -------------------------- Puzzle part 2 ----------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func SolvePart2() int {
-	var result int = 0
+func init() {
+	puzzle.Register(24, func() puzzle.Solver { return &solver{} })
+}
 
-	return result
+type solver struct {
+	hailstones []Hailstone
+	areaMin    int
+	areaMax    int
 }
 
-// -------------------------- Main entry -------------------------------------
+func (s *solver) Settings() []puzzle.Setting {
+	return []puzzle.Setting{
+		{Name: "area_min", Usage: "lower bound of the test area in part 1", Test: "7", Actual: "200000000000000"},
+		{Name: "area_max", Usage: "upper bound of the test area in part 1", Test: "27", Actual: "400000000000000"},
+	}
+}
 
-func main() {
-	var input []string
+func (s *solver) Configure(settings puzzle.Settings) error {
+	var err error
+	if s.areaMin, err = settings.Int("area_min"); err != nil {
+		return err
+	}
+	s.areaMax, err = settings.Int("area_max")
+	return err
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+func (s *solver) Parse(input []string) error {
+	var err error
+	s.hailstones, err = inputToHailstones(input)
+	return err
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func (s *solver) Part1() any {
+	return SolvePart1(s.hailstones, s.areaMin, s.areaMax)
+}
 
-	vectors := inputToVectorList(input)
-	result1 := SolvePart1(vectors)
-	result2 := SolvePart2()
-	stopwatch.Stop()
-
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	result, err := SolvePart2(s.hailstones)
+	if err != nil {
+		return err
+	}
+	return result
 }
@@ -0,0 +1,36 @@
+package day24
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/utils/number"
+)
+
+func TestSolvePart2Errors(t *testing.T) {
+	// all hailstones move alike, every system is singular
+	parallel, err := inputToHailstones([]string{
+		"0, 0, 0 @ 1, 1, 1",
+		"5, 0, 0 @ 1, 1, 1",
+		"0, 7, 0 @ 1, 1, 1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := SolvePart2(parallel); err == nil {
+		t.Errorf("Expected an error for parallel hailstones, but got %d", result)
+	}
+
+	// a resting rock at 1/2, 0, 0 is hit after 1/2, 3/2 and 5/2 nanoseconds
+	half, err := inputToHailstones([]string{
+		"0, -1, 0 @ 1, 2, 0",
+		"2, 0, -3 @ -1, 0, 2",
+		"-7, -5, -5 @ 3, 2, 2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := SolvePart2(half); !errors.Is(err, number.ErrNotInteger) {
+		t.Errorf("Expected ErrNotInteger, but got %d, %v", result, err)
+	}
+}
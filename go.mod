@@ -2,13 +2,9 @@ module github.com/cdr74/AdventOfCode2023
 
 go 1.18
 
-require (
-	github.com/pkg/profile v1.7.0
-	github.com/samber/lo v1.39.0
-)
+require github.com/pkg/profile v1.7.0
 
 require (
 	github.com/felixge/fgprof v0.9.3 // indirect
 	github.com/google/pprof v0.0.0-20211214055906-6f57359322fd // indirect
-	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17 // indirect
 )
@@ -0,0 +1,68 @@
+package puzzle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const ANSWERS_FILE string = "answers.json"
+
+// Expected holds the accepted results of both parts for one input,
+// an empty string means the answer is not known (yet)
+type Expected struct {
+	Part1 string `json:"part1,omitempty"`
+	Part2 string `json:"part2,omitempty"`
+}
+
+// Part returns the expected result of part 1 or 2
+func (e Expected) Part(part int) string {
+	if part == 1 {
+		return e.Part1
+	}
+	return e.Part2
+}
+
+// Answers maps the input kind (test, actual) to the expected results
+type Answers map[string]Expected
+
+// Kinds returns test and actual followed by the names of the other inputs
+// that have answers in alphabetical order
+func (a Answers) Kinds() []string {
+	kinds := []string{TEST_INPUT, ACTUAL_INPUT}
+	var named []string
+	for kind := range a {
+		if kind != TEST_INPUT && kind != ACTUAL_INPUT {
+			named = append(named, kind)
+		}
+	}
+	sort.Strings(named)
+	return append(kinds, named...)
+}
+
+// LoadAnswers reads dayNN/answers.json, a day without the file has no answers
+func LoadAnswers(root string, day int) (Answers, error) {
+	answers := make(Answers)
+
+	data, err := os.ReadFile(filepath.Join(root, DayDir(day), ANSWERS_FILE))
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("%s of day %d: %w", ANSWERS_FILE, day, err)
+	}
+	return answers, nil
+}
+
+// FormatResult turns the result of a part into the form used in answers.json
+func FormatResult(result any) string {
+	return fmt.Sprint(result)
+}
@@ -0,0 +1,105 @@
+package inputs
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+const CHECKSUMS_FILE string = "inputs.sum"
+
+const CHECKSUM_PREFIX string = "sha256:"
+
+// Checksums maps inputs to the checksum of their data
+type Checksums map[Key]string
+
+// Checksum returns the sha256 of the data as recorded in inputs.sum
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return CHECKSUM_PREFIX + hex.EncodeToString(sum[:])
+}
+
+// parses a key as written by Key.String, eg 2023/day05/actual
+func parseKey(s string) (Key, error) {
+	var key Key
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return key, fmt.Errorf("invalid input key %q", s)
+	}
+	if _, err := fmt.Sscanf(parts[0]+" "+parts[1], "%d day%d", &key.Year, &key.Day); err != nil {
+		return key, fmt.Errorf("invalid input key %q", s)
+	}
+	key.Kind = parts[2]
+	if err := key.Validate(); err != nil {
+		return key, err
+	}
+	if key.String() != s {
+		return key, fmt.Errorf("invalid input key %q", s)
+	}
+	return key, nil
+}
+
+// LoadChecksums reads a checksums file, one "key sha256:hex" per line.
+// A missing file has no checksums.
+func LoadChecksums(filename string) (Checksums, error) {
+	checksums := make(Checksums)
+
+	file, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return checksums, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], CHECKSUM_PREFIX) {
+			return nil, fmt.Errorf("%s line %d: expected key and %s checksum", filename, lineNo, CHECKSUM_PREFIX)
+		}
+		key, err := parseKey(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", filename, lineNo, err)
+		}
+		checksums[key] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// Save writes the checksums sorted by year, day and kind
+func (c Checksums) Save(filename string) error {
+	keys := make([]Key, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Year != keys[j].Year {
+			return keys[i].Year < keys[j].Year
+		}
+		if keys[i].Day != keys[j].Day {
+			return keys[i].Day < keys[j].Day
+		}
+		return keys[i].Kind < keys[j].Kind
+	})
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%v %s\n", key, c[key])
+	}
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
@@ -0,0 +1,73 @@
+package inputs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// layout of this repository, dayNN/test.data and dayNN/actual.data
+const DAY_PATTERN string = "day{day}/{kind}.data"
+
+// day 13 was solved in Java and keeps its input with the Maven resources
+const RESOURCES_PATTERN string = "day{day}/src/main/resources/{kind}.data"
+
+// expands {year}, {day} (two digits) and {kind} of a pattern
+func expand(pattern string, key Key) string {
+	return strings.NewReplacer(
+		"{year}", strconv.Itoa(key.Year),
+		"{day}", fmt.Sprintf("%02d", key.Day),
+		"{kind}", key.Kind,
+	).Replace(pattern)
+}
+
+// Dir reads inputs from a local directory. The patterns give the path of an
+// input relative to the root, the first existing file is used.
+type Dir struct {
+	Root     string
+	Patterns []string
+}
+
+// NewDir creates a directory source, without patterns it uses DAY_PATTERN
+func NewDir(root string, patterns ...string) *Dir {
+	if len(patterns) == 0 {
+		patterns = []string{DAY_PATTERN}
+	}
+	return &Dir{Root: root, Patterns: patterns}
+}
+
+// Path returns the file an input is read from, the path of the first pattern
+// if there is none yet
+func (d *Dir) Path(key Key) string {
+	for _, pattern := range d.Patterns {
+		path := filepath.Join(d.Root, filepath.FromSlash(expand(pattern, key)))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(d.Root, filepath.FromSlash(expand(d.Patterns[0], key)))
+}
+
+func (d *Dir) Fetch(key Key) ([]byte, error) {
+	data, err := os.ReadFile(d.Path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put stores an input under the path of the first pattern
+func (d *Dir) Put(key Key, data []byte) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	path := filepath.Join(d.Root, filepath.FromSlash(expand(d.Patterns[0], key)))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
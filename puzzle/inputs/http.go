@@ -0,0 +1,65 @@
+package inputs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+)
+
+const AOC_URL string = "https://adventofcode.com"
+
+// identifies the requests as asked for by the Advent of Code site
+const USER_AGENT string = "github.com/cdr74/AdventOfCode2023"
+
+// HTTP downloads actual inputs from the Advent of Code site, or any server
+// serving {base}/{year}/day/{day}/input. Only the actual input can be
+// downloaded, the examples are part of the puzzle text.
+type HTTP struct {
+	BaseURL string
+	Session string
+	Client  *http.Client
+}
+
+// NewHTTP creates a source for the Advent of Code site logged in with the
+// session cookie
+func NewHTTP(session string) *HTTP {
+	return &HTTP{BaseURL: AOC_URL, Session: session, Client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (h *HTTP) Fetch(key Key) ([]byte, error) {
+	if key.Kind != puzzle.ACTUAL_INPUT {
+		return nil, ErrNotFound
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(h.BaseURL, "/"), key.Year, key.Day)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", USER_AGENT)
+	request.AddCookie(&http.Cookie{Name: "session", Value: h.Session})
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(response.Body)
+	case http.StatusNotFound:
+		// puzzle not unlocked yet
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("GET %s: %s", url, response.Status)
+	}
+}
@@ -0,0 +1,281 @@
+package inputs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const TEST_DATA string = "first\nsecond\n"
+
+func writeFile(t *testing.T, filename string, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// creates an archive with the given files, gzip compressed if zipped
+func writeTarball(t *testing.T, filename string, files map[string]string, zipped bool) {
+	t.Helper()
+	var buffer bytes.Buffer
+	archive := tar.NewWriter(&buffer)
+	for name, data := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buffer.Bytes()
+	if zipped {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		gz.Write(data)
+		gz.Close()
+		data = compressed.Bytes()
+	}
+	writeFile(t, filename, string(data))
+}
+
+func TestKeyValidate(t *testing.T) {
+	tests := []struct {
+		key   Key
+		valid bool
+	}{
+		{KeyOf(5, "test"), true},
+		{KeyOf(25, "stress_2"), true},
+		{KeyOf(0, "test"), false},
+		{KeyOf(26, "test"), false},
+		{KeyOf(5, ""), false},
+		{KeyOf(5, "../actual"), false},
+	}
+	for _, test := range tests {
+		if err := test.key.Validate(); (err == nil) != test.valid {
+			t.Errorf("Expected %v valid to be %v, but got %v", test.key, test.valid, err)
+		}
+	}
+}
+
+func TestDirPatterns(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "day05", "test.data"), "day 5")
+	writeFile(t, filepath.Join(root, "day13", "src", "main", "resources", "test.data"), "day 13")
+
+	dir := NewDir(root, DAY_PATTERN, RESOURCES_PATTERN)
+	for _, test := range []struct {
+		day      int
+		expected string
+	}{{5, "day 5"}, {13, "day 13"}} {
+		data, err := dir.Fetch(KeyOf(test.day, "test"))
+		if err != nil || string(data) != test.expected {
+			t.Errorf("Expected %q, but got %q, %v", test.expected, data, err)
+		}
+	}
+
+	if _, err := dir.Fetch(KeyOf(5, "actual")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, but got %v", err)
+	}
+}
+
+func TestDirPut(t *testing.T) {
+	dir := NewDir(t.TempDir(), "{year}/day{day}/{kind}.txt")
+	key := KeyOf(7, "stress")
+	if err := dir.Put(key, []byte(TEST_DATA)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := filepath.Join(dir.Root, "2023", "day07", "stress.txt"); dir.Path(key) != expected {
+		t.Errorf("Expected %s, but got %s", expected, dir.Path(key))
+	}
+	if data, err := dir.Fetch(key); err != nil || string(data) != TEST_DATA {
+		t.Errorf("Expected %q, but got %q, %v", TEST_DATA, data, err)
+	}
+}
+
+func TestTarball(t *testing.T) {
+	files := map[string]string{
+		"./day05/test.data":  "plain test",
+		"day05/actual.data":  "plain actual",
+		"day06/notes.md":     "not an input",
+		"other/day07/x.data": "not matched",
+	}
+	for _, zipped := range []bool{false, true} {
+		filename := filepath.Join(t.TempDir(), "inputs.tar")
+		writeTarball(t, filename, files, zipped)
+		tarball := NewTarball(filename)
+
+		for kind, expected := range map[string]string{"test": "plain test", "actual": "plain actual"} {
+			data, err := tarball.Fetch(KeyOf(5, kind))
+			if err != nil || string(data) != expected {
+				t.Errorf("zipped %v: Expected %q, but got %q, %v", zipped, expected, data, err)
+			}
+		}
+		if _, err := tarball.Fetch(KeyOf(7, "x")); !errors.Is(err, ErrNotFound) {
+			t.Errorf("zipped %v: Expected ErrNotFound, but got %v", zipped, err)
+		}
+	}
+}
+
+func TestTarballMissing(t *testing.T) {
+	tarball := NewTarball(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	if _, err := tarball.Fetch(KeyOf(5, "test")); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected an error reading the archive, but got %v", err)
+	}
+}
+
+// stands in for adventofcode.com, serves day 5 only
+func newServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/2023/day/5/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(TEST_DATA))
+	}))
+}
+
+func TestHTTP(t *testing.T) {
+	requests := 0
+	server := newServer(t, &requests)
+	defer server.Close()
+
+	source := &HTTP{BaseURL: server.URL, Session: "secret", Client: server.Client()}
+	if data, err := source.Fetch(KeyOf(5, "actual")); err != nil || string(data) != TEST_DATA {
+		t.Errorf("Expected %q, but got %q, %v", TEST_DATA, data, err)
+	}
+	if _, err := source.Fetch(KeyOf(6, "actual")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a locked day, but got %v", err)
+	}
+	if _, err := source.Fetch(KeyOf(5, "test")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for the test input, but got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, but got %d", requests)
+	}
+
+	source.Session = "wrong"
+	if _, err := source.Fetch(KeyOf(5, "actual")); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected an error for a wrong session, but got %v", err)
+	}
+}
+
+func TestStoreCachesFetchedInputs(t *testing.T) {
+	requests := 0
+	server := newServer(t, &requests)
+	defer server.Close()
+
+	dir := NewDir(t.TempDir())
+	source := &HTTP{BaseURL: server.URL, Session: "secret", Client: server.Client()}
+	store := NewStore(dir, source).WithCache(dir)
+
+	for i := 0; i < 2; i++ {
+		lines, err := store.Lines(KeyOf(5, "actual"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := []string{"first", "second"}; !reflect.DeepEqual(lines, expected) {
+			t.Errorf("Expected %v, but got %v", expected, lines)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the second read from the cache, but got %d requests", requests)
+	}
+	if _, err := os.Stat(filepath.Join(dir.Root, "day05", "actual.data")); err != nil {
+		t.Errorf("Expected the input in the cache directory, but got %v", err)
+	}
+
+	if _, err := store.Lines(KeyOf(6, "actual")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, but got %v", err)
+	}
+}
+
+func TestStoreSourceOrder(t *testing.T) {
+	first, second := NewDir(t.TempDir()), NewDir(t.TempDir())
+	writeFile(t, first.Path(KeyOf(1, "test")), "first")
+	writeFile(t, second.Path(KeyOf(1, "test")), "second")
+	writeFile(t, second.Path(KeyOf(2, "test")), "only second")
+
+	store := NewStore(first, second)
+	for day, expected := range map[int]string{1: "first", 2: "only second"} {
+		if data, err := store.Bytes(KeyOf(day, "test")); err != nil || string(data) != expected {
+			t.Errorf("Expected %q, but got %q, %v", expected, data, err)
+		}
+	}
+}
+
+func TestStoreChecksums(t *testing.T) {
+	dir := NewDir(t.TempDir())
+	key := KeyOf(5, "test")
+	writeFile(t, dir.Path(key), TEST_DATA)
+
+	store := NewStore(dir).WithChecksums(Checksums{key: Checksum([]byte(TEST_DATA))})
+	if _, err := store.Bytes(key); err != nil {
+		t.Errorf("Expected a matching checksum, but got %v", err)
+	}
+
+	writeFile(t, dir.Path(key), "changed\n")
+	if _, err := store.Bytes(key); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected ErrChecksum, but got %v", err)
+	}
+}
+
+func TestChecksumsSaveAndLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), CHECKSUMS_FILE)
+	checksums := Checksums{
+		KeyOf(12, "actual"): Checksum([]byte("12")),
+		KeyOf(2, "test"):    Checksum([]byte("2")),
+		{2022, 25, "test"}:  Checksum([]byte("2022")),
+	}
+	if err := checksums.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadChecksums(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, checksums) {
+		t.Errorf("Expected %v, but got %v", checksums, loaded)
+	}
+
+	data, _ := os.ReadFile(filename)
+	if first := string(bytes.SplitN(data, []byte(" "), 2)[0]); first != "2022/day25/test" {
+		t.Errorf("Expected the checksums sorted by year, but the first is %s", first)
+	}
+}
+
+func TestLoadChecksumsInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), CHECKSUMS_FILE)
+	for _, content := range []string{"2023/day05/test\n", "2023/day05/test md5:abc\n", "2023/day5x/test sha256:abc\n"} {
+		writeFile(t, filename, content)
+		if _, err := LoadChecksums(filename); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+
+	if checksums, err := LoadChecksums(filepath.Join(t.TempDir(), "missing.sum")); err != nil || len(checksums) != 0 {
+		t.Errorf("Expected no checksums for a missing file, but got %v, %v", checksums, err)
+	}
+}
@@ -0,0 +1,136 @@
+// ---------------------------------------------------------------------------
+// Store for the puzzle inputs.
+//
+// An input is identified by year, day and kind: test, actual or the name of
+// a custom input. The store asks its sources in order, the first one that
+// has the input wins. Inputs fetched from elsewhere are written to the cache
+// directory, and every input with a known checksum is verified on read.
+// ---------------------------------------------------------------------------
+package inputs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// ErrNotFound is returned if no source has the input
+var ErrNotFound = errors.New("input not found")
+
+// ErrChecksum is returned if an input does not match its recorded checksum
+var ErrChecksum = errors.New("checksum mismatch")
+
+// kinds end up in file and archive names, so only plain names are allowed
+var kindPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Key identifies a single input
+type Key struct {
+	Year int
+	Day  int
+	Kind string
+}
+
+// KeyOf returns the key of an input of this year's event
+func KeyOf(day int, kind string) Key {
+	return Key{Year: puzzle.YEAR, Day: day, Kind: kind}
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%d/%s/%s", k.Year, puzzle.DayDir(k.Day), k.Kind)
+}
+
+// Validate checks that day and kind can be used in a path
+func (k Key) Validate() error {
+	if k.Day < 1 || k.Day > 25 {
+		return fmt.Errorf("%v: day must be 1..25", k)
+	}
+	if !kindPattern.MatchString(k.Kind) {
+		return fmt.Errorf("%v: kind must only contain letters, digits, _ and -", k)
+	}
+	return nil
+}
+
+// Source provides the raw data of inputs, Fetch returns ErrNotFound for
+// inputs the source does not have
+type Source interface {
+	Fetch(key Key) ([]byte, error)
+}
+
+// Store reads inputs from a list of sources
+type Store struct {
+	sources   []Source
+	cache     *Dir
+	checksums Checksums
+}
+
+// NewStore creates a store asking the sources in the given order
+func NewStore(sources ...Source) *Store {
+	return &Store{sources: sources, checksums: make(Checksums)}
+}
+
+// WithCache writes inputs that were not read from dir into it, so they are
+// fetched only once
+func (s *Store) WithCache(dir *Dir) *Store {
+	s.cache = dir
+	return s
+}
+
+// WithChecksums verifies inputs against the given checksums
+func (s *Store) WithChecksums(checksums Checksums) *Store {
+	s.checksums = checksums
+	return s
+}
+
+// Bytes returns the raw data of an input
+func (s *Store) Bytes(key Key) ([]byte, error) {
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, source := range s.sources {
+		data, err := source.Fetch(key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", key, err)
+		}
+
+		if expected, ok := s.checksums[key]; ok && expected != Checksum(data) {
+			return nil, fmt.Errorf("%v: %w, expected %s but got %s", key, ErrChecksum, expected, Checksum(data))
+		}
+		if s.cache != nil && source != Source(s.cache) {
+			if err := s.cache.Put(key, data); err != nil {
+				return nil, fmt.Errorf("%v: %w", key, err)
+			}
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%v: %w", key, ErrNotFound)
+}
+
+// Lines returns an input split into lines, the way the solvers parse it
+func (s *Store) Lines(key Key) ([]string, error) {
+	data, err := s.Bytes(key)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ReadLines(bytes.NewReader(data), key.String())
+}
+
+// Repository creates the store of this repository: inputs are read from the
+// day directories below root and checked against root/inputs.sum, inputs of
+// the other sources are saved to the day directories.
+func Repository(root string, sources ...Source) (*Store, error) {
+	checksums, err := LoadChecksums(filepath.Join(root, CHECKSUMS_FILE))
+	if err != nil {
+		return nil, err
+	}
+	dir := NewDir(root, DAY_PATTERN, RESOURCES_PATTERN)
+	return NewStore(append([]Source{dir}, sources...)...).WithCache(dir).WithChecksums(checksums), nil
+}
@@ -0,0 +1,89 @@
+package inputs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"sync"
+)
+
+// Tarball reads inputs from a tar archive, gzip compressed or not. Entries
+// are matched with the same patterns as a Dir, the archive is read once on
+// first use.
+type Tarball struct {
+	Path     string
+	Patterns []string
+
+	once    sync.Once
+	entries map[string][]byte
+	err     error
+}
+
+// NewTarball creates an archive source, without patterns it uses DAY_PATTERN
+func NewTarball(path string, patterns ...string) *Tarball {
+	if len(patterns) == 0 {
+		patterns = []string{DAY_PATTERN}
+	}
+	return &Tarball{Path: path, Patterns: patterns}
+}
+
+func (t *Tarball) Fetch(key Key) ([]byte, error) {
+	t.once.Do(func() { t.entries, t.err = readTarball(t.Path) })
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	for _, pattern := range t.Patterns {
+		if data, ok := t.entries[expand(pattern, key)]; ok {
+			return data, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// reads all regular files of an archive, names are cleaned so "./day05/test.data"
+// matches "day05/test.data"
+func readTarball(filename string) (map[string][]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReader(file)
+	var reader io.Reader = buffered
+	magic, err := buffered.Peek(2)
+	if err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	entries := make(map[string][]byte)
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		entries[path.Clean(header.Name)] = data
+	}
+}
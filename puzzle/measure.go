@@ -0,0 +1,79 @@
+package puzzle
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// Measurement is the cost of one phase (parse, part 1 or part 2) of a run
+type Measurement struct {
+	Elapsed    time.Duration
+	Allocs     uint64 // heap allocations
+	AllocBytes uint64 // bytes allocated on the heap
+	PeakHeap   uint64 // highest heap in use, sampled every millisecond
+}
+
+const METRIC_HEAP_IN_USE = "/memory/classes/heap/objects:bytes"
+const PEAK_SAMPLE_INTERVAL = time.Millisecond
+
+// reading runtime/metrics does not stop the world, unlike runtime.ReadMemStats
+func heapInUse() uint64 {
+	samples := []metrics.Sample{{Name: METRIC_HEAP_IN_USE}}
+	metrics.Read(samples)
+	return samples[0].Value.Uint64()
+}
+
+// Measure runs fn once and records time, allocations and peak heap. A garbage
+// collection runs first so the peak is not inflated by garbage of earlier phases.
+func Measure(fn func()) Measurement {
+	runtime.GC()
+
+	var peak uint64
+	var mutex sync.Mutex
+	updatePeak := func(heap uint64) {
+		mutex.Lock()
+		if heap > peak {
+			peak = heap
+		}
+		mutex.Unlock()
+	}
+
+	stop := make(chan struct{})
+	var sampler sync.WaitGroup
+	sampler.Add(1)
+	go func() {
+		defer sampler.Done()
+		ticker := time.NewTicker(PEAK_SAMPLE_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				updatePeak(heapInUse())
+			}
+		}
+	}()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	updatePeak(before.HeapAlloc)
+	start := time.Now()
+
+	fn()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	updatePeak(after.HeapAlloc)
+	close(stop)
+	sampler.Wait()
+
+	return Measurement{
+		Elapsed:    elapsed,
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+		PeakHeap:   peak,
+	}
+}
@@ -0,0 +1,66 @@
+// ---------------------------------------------------------------------------
+// Registry of all puzzle solvers.
+//
+// Every day registers itself from an init function, the aoc command then
+// looks up a day by number and runs parse, part 1 and part 2 on it.
+// ---------------------------------------------------------------------------
+package puzzle
+
+import (
+	"fmt"
+	"sort"
+)
+
+// YEAR is the Advent of Code event all days belong to
+const YEAR int = 2023
+
+const TEST_INPUT string = "test"
+const ACTUAL_INPUT string = "actual"
+const CUSTOM_INPUT string = "custom"
+
+// Solver is implemented by each day. A new Solver is created for every run,
+// Parse is always called before Part1 or Part2. A part returns an error as
+// its result if the input has no answer.
+type Solver interface {
+	Parse(input []string) error
+	Part1() any
+	Part2() any
+}
+
+// Factory creates a fresh Solver for a single run
+type Factory func() Solver
+
+var registry = make(map[int]Factory)
+
+// Register makes a solver available under the given day number,
+// registering the same day twice is a programming error
+func Register(day int, factory Factory) {
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("puzzle.Register() - day %d registered twice", day))
+	}
+	registry[day] = factory
+}
+
+// Lookup returns a new Solver for the given day
+func Lookup(day int) (Solver, error) {
+	factory, ok := registry[day]
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for day %d", day)
+	}
+	return factory(), nil
+}
+
+// Days returns all registered day numbers in ascending order
+func Days() []int {
+	days := make([]int, 0, len(registry))
+	for day := range registry {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// DayDir returns the directory of a day relative to the repository root, eg "day07"
+func DayDir(day int) string {
+	return fmt.Sprintf("day%02d", day)
+}
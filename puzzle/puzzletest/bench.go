@@ -0,0 +1,89 @@
+// ---------------------------------------------------------------------------
+// Helpers for the tests and benchmarks of the day packages.
+//
+// The tests run in the day directory, so the inputs are read from the store
+// of the repository root "..".
+// ---------------------------------------------------------------------------
+package puzzletest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/puzzle/inputs"
+)
+
+const ROOT string = ".."
+
+var kinds = []string{puzzle.TEST_INPUT, puzzle.ACTUAL_INPUT}
+
+// reads the input of a day, skips the test or benchmark if the input is not there
+func readInput(b testing.TB, day int, kind string) []string {
+	store, err := inputs.Repository(ROOT)
+	if err != nil {
+		b.Fatal(err)
+	}
+	lines, err := store.Lines(inputs.KeyOf(day, kind))
+	if errors.Is(err, inputs.ErrNotFound) {
+		b.Skip(err)
+	}
+	if err != nil {
+		b.Fatal(err)
+	}
+	return lines
+}
+
+// creates a configured solver for the given input kind
+func newSolver(b testing.TB, day int, kind string) puzzle.Solver {
+	solver, err := puzzle.Lookup(day)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := puzzle.Configure(solver, day, kind, nil); err != nil {
+		b.Fatal(err)
+	}
+	return solver
+}
+
+// BenchmarkParse benchmarks Parse of a day on test and actual input
+func BenchmarkParse(b *testing.B, day int) {
+	for _, kind := range kinds {
+		b.Run(kind, func(b *testing.B) {
+			lines := readInput(b, day, kind)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				solver := newSolver(b, day, kind)
+				b.StartTimer()
+				if err := solver.Parse(lines); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPart benchmarks part 1 or 2 of a day on test and actual input,
+// every iteration solves on a freshly parsed solver
+func BenchmarkPart(b *testing.B, day int, part int) {
+	for _, kind := range kinds {
+		b.Run(kind, func(b *testing.B) {
+			lines := readInput(b, day, kind)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				solver := newSolver(b, day, kind)
+				if err := solver.Parse(lines); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+				if part == 1 {
+					solver.Part1()
+				} else {
+					solver.Part2()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package puzzletest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+	"github.com/cdr74/AdventOfCode2023/puzzle/inputs"
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// FuzzParse feeds the test input of a day and mutations of it to Parse of a
+// fresh solver. Malformed input has to be reported as error, a panic fails.
+// The whole input and each line of it are the seeds.
+func FuzzParse(f *testing.F, day int) {
+	store, err := inputs.Repository(ROOT)
+	if err != nil {
+		f.Fatal(err)
+	}
+	data, err := store.Bytes(inputs.KeyOf(day, puzzle.TEST_INPUT))
+	if errors.Is(err, inputs.ErrNotFound) {
+		f.Skip(err)
+	}
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(string(data))
+	for _, line := range strings.Split(string(data), "\n") {
+		f.Add(line)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		lines, err := utils.ReadLines(strings.NewReader(input), "fuzz")
+		if err != nil {
+			t.Skip(err)
+		}
+		solver := newSolver(t, day, puzzle.TEST_INPUT)
+		// errors are fine, only panics are bugs
+		solver.Parse(lines)
+	})
+}
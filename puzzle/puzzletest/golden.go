@@ -0,0 +1,60 @@
+package puzzletest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/puzzle"
+)
+
+// a part of a day on one input and its accepted answer
+type goldenCase struct {
+	kind     string
+	part     int
+	expected string
+}
+
+// Golden solves both parts of a day on test and actual input and on every
+// named input in the answers.json of the day, eg the second example of day 17
+// in example2.data, and compares the results with the answers. Every case
+// parses into a fresh solver, so state left behind by one case would break
+// the next. Parts without an answer are skipped, actual inputs in short mode.
+func Golden(t *testing.T, day int) {
+	answers, err := puzzle.LoadAnswers(ROOT, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []goldenCase
+	for _, kind := range answers.Kinds() {
+		for part := 1; part <= 2; part++ {
+			cases = append(cases, goldenCase{kind, part, answers[kind].Part(part)})
+		}
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s/part%d", c.kind, c.part), func(t *testing.T) {
+			if c.expected == "" {
+				t.Skipf("no answer for %s part %d", c.kind, c.part)
+			}
+			if testing.Short() && c.kind == puzzle.ACTUAL_INPUT {
+				t.Skip("actual input in short mode")
+			}
+
+			lines := readInput(t, day, c.kind)
+			solver := newSolver(t, day, c.kind)
+			if err := solver.Parse(lines); err != nil {
+				t.Fatal(err)
+			}
+			var result any
+			if c.part == 1 {
+				result = solver.Part1()
+			} else {
+				result = solver.Part2()
+			}
+			if got := puzzle.FormatResult(result); got != c.expected {
+				t.Errorf("Expected %s, but got %s", c.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package puzzle
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Setting is a named puzzle parameter, eg the test area of day 24. The puzzle
+// text uses different values for the example and the actual input, custom
+// inputs get the actual default.
+type Setting struct {
+	Name   string
+	Usage  string
+	Test   string
+	Actual string
+}
+
+// Settings holds the value of each setting by name
+type Settings map[string]string
+
+// Int returns the named setting as int
+func (s Settings) Int(name string) (int, error) {
+	value, ok := s[name]
+	if !ok {
+		return 0, fmt.Errorf("setting %s not defined", name)
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("setting %s: %w", name, err)
+	}
+	return i, nil
+}
+
+// Configurable is implemented by solvers that have puzzle parameters,
+// Configure is called before Parse
+type Configurable interface {
+	Settings() []Setting
+	Configure(settings Settings) error
+}
+
+// EnvName returns the environment variable overriding a setting, eg AOC_DAY24_AREA_MIN
+func EnvName(day int, name string) string {
+	return fmt.Sprintf("AOC_DAY%02d_%s", day, strings.ToUpper(name))
+}
+
+// Configure passes the settings of a solver for the given input kind. Defaults
+// are overridden by environment variables, which are overridden by overrides.
+func Configure(solver Solver, day int, kind string, overrides Settings) error {
+	configurable, ok := solver.(Configurable)
+	if !ok {
+		if len(overrides) > 0 {
+			return fmt.Errorf("day %d has no settings", day)
+		}
+		return nil
+	}
+
+	settings := make(Settings)
+	for _, setting := range configurable.Settings() {
+		if kind == TEST_INPUT {
+			settings[setting.Name] = setting.Test
+		} else {
+			settings[setting.Name] = setting.Actual
+		}
+		if value, ok := os.LookupEnv(EnvName(day, setting.Name)); ok {
+			settings[setting.Name] = value
+		}
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := settings[name]; !ok {
+			return fmt.Errorf("day %d has no setting %s", day, name)
+		}
+		settings[name] = overrides[name]
+	}
+
+	return configurable.Configure(settings)
+}
@@ -9,25 +9,17 @@
 // Part 1:
 // Part 2:
 // ---------------------------------------------------------------------------
-package main
+package template
 
 import (
-	"fmt"
-	"time"
-
-	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/puzzle"
 )
 
-// runTest defines whether test.data or actual.data should be used
-const runTest bool = true
-const TEST_FILE string = "test.data"
-const DATA_FILE string = "actual.data"
-
 // -------------------------- Common Code Section ----------------------------
 
 // -------------------------- Puzzle part 1 ----------------------------------
 
-func SolvePart1() int {
+func SolvePart1(input []string) int {
 	var result int = 0
 
 	return result
@@ -35,34 +27,32 @@ func SolvePart1() int {
 
 // -------------------------- Puzzle part 2 ----------------------------------
 
-func SolvePart2() int {
+func SolvePart2(input []string) int {
 	var result int = 0
 
 	return result
 }
 
-// -------------------------- Main entry -------------------------------------
+// -------------------------- Solver entry -----------------------------------
 
-func main() {
-	var input []string
+// aoc new --day N creates the dayNN package from this template
+func init() {
+	puzzle.Register(0, func() puzzle.Solver { return &solver{} })
+}
 
-	stopwatch := utils.NewStopwatch()
-	stopwatch.Start()
+type solver struct {
+	input []string
+}
 
-	if runTest {
-		input = utils.ReadDataFile(TEST_FILE)
-	} else {
-		input = utils.ReadDataFile(DATA_FILE)
-	}
+func (s *solver) Parse(input []string) error {
+	s.input = input
+	return nil
+}
 
-	result1 := SolvePart1()
-	result2 := SolvePart2()
-	stopwatch.Stop()
+func (s *solver) Part1() any {
+	return SolvePart1(s.input)
+}
 
-	// ---------------------- Print results ----------------------------------
-	var elapsedTime time.Duration = stopwatch.GetElapsedTime()
-	fmt.Println("Running as test:\t", runTest)
-	fmt.Println("Result 1:\t\t", result1)
-	fmt.Println("Result 2:\t\t", result2)
-	fmt.Println("Elapsed time:\t\t", elapsedTime)
+func (s *solver) Part2() any {
+	return SolvePart2(s.input)
 }
@@ -1,31 +1,248 @@
 package utils
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
 
+// Stopwatch measures the total time of a run and optionally named laps, eg
+// parse, part1 and part2. Laps can be nested and paused, all methods are safe
+// to use from several goroutines.
 type Stopwatch struct {
+	mutex sync.Mutex
+	timer
+	laps []*Lap
+}
+
+// Lap is a named span of a Stopwatch, created by Stopwatch.Lap or Lap.Lap
+type Lap struct {
+	watch *Stopwatch
+	name  string
+	timer
+	laps []*Lap
+}
+
+// LapSummary is the elapsed time of all laps with the same name and parent
+type LapSummary struct {
+	Name    string        `json:"name"`
+	Count   int           `json:"count"`
+	Elapsed time.Duration `json:"elapsed_ns"`
+	Laps    []LapSummary  `json:"laps,omitempty"`
+}
+
+// Summary is the report of a Stopwatch
+type Summary struct {
+	Elapsed time.Duration `json:"elapsed_ns"`
+	Laps    []LapSummary  `json:"laps,omitempty"`
+}
+
+// accumulates time between start and stop, callers hold the mutex
+type timer struct {
 	startTime   time.Time
 	elapsedTime time.Duration
 	isRunning   bool
 }
 
-func NewStopwatch() *Stopwatch {
-	return &Stopwatch{
-		startTime:   time.Now(),
-		elapsedTime: 0,
-		isRunning:   false,
+func (t *timer) start() {
+	if !t.isRunning {
+		t.isRunning = true
+		t.startTime = time.Now()
+	}
+}
+
+func (t *timer) stop() {
+	if t.isRunning {
+		t.isRunning = false
+		t.elapsedTime += time.Since(t.startTime)
+	}
+}
+
+func (t *timer) elapsed() time.Duration {
+	if t.isRunning {
+		return t.elapsedTime + time.Since(t.startTime)
 	}
+	return t.elapsedTime
 }
 
+// -------------------------- Stopwatch --------------------------------------
+
+func NewStopwatch() *Stopwatch {
+	return &Stopwatch{}
+}
+
+// GetElapsedTime returns the accumulated time, including the current run if running
 func (s *Stopwatch) GetElapsedTime() time.Duration {
-	return s.elapsedTime
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.elapsed()
 }
 
+// Start resets the stopwatch, drops all laps and starts measuring
 func (s *Stopwatch) Start() {
-	s.isRunning = true
-	s.startTime = time.Now()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.timer = timer{}
+	s.laps = nil
+	s.start()
 }
 
+// Stop stops the stopwatch and all laps still running
 func (s *Stopwatch) Stop() {
-	s.isRunning = false
-	s.elapsedTime = time.Since(s.startTime)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stop()
+	for _, lap := range s.laps {
+		lap.stopAll()
+	}
+}
+
+// Pause stops measuring without touching the laps, Resume continues and
+// accumulates the elapsed time
+func (s *Stopwatch) Pause() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stop()
+}
+
+func (s *Stopwatch) Resume() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.start()
+}
+
+// Lap starts a new top level lap
+func (s *Stopwatch) Lap(name string) *Lap {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	lap := &Lap{watch: s, name: name}
+	lap.start()
+	s.laps = append(s.laps, lap)
+	return lap
+}
+
+// Time runs fn as a lap with the given name
+func (s *Stopwatch) Time(name string, fn func()) {
+	lap := s.Lap(name)
+	defer lap.Stop()
+	fn()
+}
+
+// -------------------------- Lap --------------------------------------------
+
+// Lap starts a new lap nested in this one
+func (l *Lap) Lap(name string) *Lap {
+	l.watch.mutex.Lock()
+	defer l.watch.mutex.Unlock()
+	lap := &Lap{watch: l.watch, name: name}
+	lap.start()
+	l.laps = append(l.laps, lap)
+	return lap
+}
+
+// Time runs fn as a nested lap with the given name
+func (l *Lap) Time(name string, fn func()) {
+	lap := l.Lap(name)
+	defer lap.Stop()
+	fn()
+}
+
+func (l *Lap) Name() string {
+	return l.name
+}
+
+func (l *Lap) GetElapsedTime() time.Duration {
+	l.watch.mutex.Lock()
+	defer l.watch.mutex.Unlock()
+	return l.elapsed()
+}
+
+// Stop ends the lap and all its nested laps still running
+func (l *Lap) Stop() {
+	l.watch.mutex.Lock()
+	defer l.watch.mutex.Unlock()
+	l.stopAll()
+}
+
+func (l *Lap) Pause() {
+	l.watch.mutex.Lock()
+	defer l.watch.mutex.Unlock()
+	l.stop()
+}
+
+func (l *Lap) Resume() {
+	l.watch.mutex.Lock()
+	defer l.watch.mutex.Unlock()
+	l.start()
+}
+
+func (l *Lap) stopAll() {
+	l.stop()
+	for _, lap := range l.laps {
+		lap.stopAll()
+	}
+}
+
+// -------------------------- Summary ----------------------------------------
+
+// Summary returns the elapsed times, laps with the same name and parent are
+// added up in order of their first start
+func (s *Stopwatch) Summary() Summary {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return Summary{Elapsed: s.elapsed(), Laps: summarizeLaps(s.laps)}
+}
+
+func summarizeLaps(laps []*Lap) []LapSummary {
+	var result []LapSummary
+	var children [][]*Lap
+	index := make(map[string]int)
+	for _, lap := range laps {
+		idx, ok := index[lap.name]
+		if !ok {
+			idx = len(result)
+			index[lap.name] = idx
+			result = append(result, LapSummary{Name: lap.name})
+			children = append(children, nil)
+		}
+		result[idx].Count++
+		result[idx].Elapsed += lap.elapsed()
+		children[idx] = append(children[idx], lap.laps...)
+	}
+	for idx := range result {
+		result[idx].Laps = summarizeLaps(children[idx])
+	}
+	return result
+}
+
+// PrintSummary writes the laps as indented table with their share of the total time
+func (s *Stopwatch) PrintSummary(w io.Writer) {
+	summary := s.Summary()
+	fmt.Fprintf(w, "%-24s %14v\n", "total", summary.Elapsed)
+	printLaps(w, summary.Laps, summary.Elapsed, 1)
+}
+
+func printLaps(w io.Writer, laps []LapSummary, total time.Duration, depth int) {
+	for _, lap := range laps {
+		name := strings.Repeat("  ", depth) + lap.Name
+		if lap.Count > 1 {
+			name = fmt.Sprintf("%s (%dx)", name, lap.Count)
+		}
+		share := 0.0
+		if total > 0 {
+			share = 100 * float64(lap.Elapsed) / float64(total)
+		}
+		fmt.Fprintf(w, "%-24s %14v %6.1f%%\n", name, lap.Elapsed, share)
+		printLaps(w, lap.Laps, total, depth+1)
+	}
+}
+
+// WriteJSON writes the summary as JSON, durations are in nanoseconds
+func (s *Stopwatch) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(s.Summary())
 }
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStopwatchNestedLaps(t *testing.T) {
+	stopwatch := NewStopwatch()
+	stopwatch.Start()
+	part := stopwatch.Lap("part1")
+	for i := 0; i < 3; i++ {
+		part.Time("memo", func() { time.Sleep(time.Millisecond) })
+	}
+	stopwatch.Stop()
+
+	summary := stopwatch.Summary()
+	if len(summary.Laps) != 1 || summary.Laps[0].Name != "part1" {
+		t.Fatalf("Expected a single lap part1, but got %+v", summary.Laps)
+	}
+	nested := summary.Laps[0].Laps
+	if len(nested) != 1 || nested[0].Count != 3 {
+		t.Fatalf("Expected memo to be counted 3 times, but got %+v", nested)
+	}
+	if nested[0].Elapsed > summary.Laps[0].Elapsed || summary.Laps[0].Elapsed > summary.Elapsed {
+		t.Errorf("Expected nested laps to be within their parent, but got %+v", summary)
+	}
+	if part.GetElapsedTime() != summary.Laps[0].Elapsed {
+		t.Errorf("Expected Stop to stop the running lap")
+	}
+}
+
+func TestStopwatchPauseResume(t *testing.T) {
+	stopwatch := NewStopwatch()
+	stopwatch.Start()
+	time.Sleep(time.Millisecond)
+	stopwatch.Pause()
+	paused := stopwatch.GetElapsedTime()
+	time.Sleep(5 * time.Millisecond)
+	if stopwatch.GetElapsedTime() != paused {
+		t.Errorf("Expected no time to pass while paused")
+	}
+	stopwatch.Resume()
+	time.Sleep(time.Millisecond)
+	stopwatch.Stop()
+	if stopwatch.GetElapsedTime() <= paused {
+		t.Errorf("Expected resume to accumulate, but got %v after %v", stopwatch.GetElapsedTime(), paused)
+	}
+}
+
+func TestStopwatchConcurrentLaps(t *testing.T) {
+	stopwatch := NewStopwatch()
+	stopwatch.Start()
+	part := stopwatch.Lap("part2")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			part.Time("worker", func() { time.Sleep(time.Millisecond) })
+			stopwatch.Summary()
+		}()
+	}
+	wg.Wait()
+	stopwatch.Stop()
+
+	workers := stopwatch.Summary().Laps[0].Laps
+	if len(workers) != 1 || workers[0].Count != 8 {
+		t.Errorf("Expected 8 worker laps, but got %+v", workers)
+	}
+}
@@ -1,27 +1,35 @@
 package utils
 
 import (
-	"log"
+	"fmt"
 	"strconv"
 	"strings"
 )
 
-func StringToUint64(s string) uint64 {
-	i, err := strconv.ParseInt(s, 10, 0)
+// surrounding blanks are ignored, the error names the offending string
+func StringToUint64(s string) (uint64, error) {
+	i, err := strconv.ParseUint(strings.Trim(s, " "), 10, 64)
 	if err != nil {
-		// log.Fates does exit the prog
-		log.Fatal(err)
+		return 0, numError(err)
 	}
-	return uint64(i)
+	return i, nil
 }
 
-func StringToInt(s string) int {
+// surrounding blanks are ignored, the error names the offending string
+func StringToInt(s string) (int, error) {
 	i, err := strconv.ParseInt(strings.Trim(s, " "), 10, 0)
 	if err != nil {
-		// log.Fates does exit the prog
-		log.Fatal(err)
+		return 0, numError(err)
 	}
-	return int(i)
+	return int(i), nil
+}
+
+// strips the function name of strconv errors, eg `invalid syntax "x1"`
+func numError(err error) error {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		return fmt.Errorf("%w %q", numErr.Err, numErr.Num)
+	}
+	return err
 }
 
 func IntToString(i int) string {
@@ -30,15 +38,18 @@ func IntToString(i int) string {
 }
 
 // assumes each digit is an int
-func StringToIntArray(input string) []int {
+func StringToIntArray(input string) ([]int, error) {
 	intArray := make([]int, 0, len(input))
 
 	for _, char := range input {
-		num := StringToInt(string(char))
+		num, err := StringToInt(string(char))
+		if err != nil {
+			return nil, err
+		}
 		intArray = append(intArray, num)
 	}
 
-	return intArray
+	return intArray, nil
 }
 
 func StringToByteArray(s string) []byte {
@@ -0,0 +1,90 @@
+package utils
+
+import "fmt"
+
+// Cycle is a sequence of states that eventually repeats, the state after
+// Start steps is the first one seen again after Length more steps
+type Cycle[S any] struct {
+	Start  int
+	Length int
+
+	initial S
+	step    func(S) S
+	// states after 0 up to Start+Length-1 steps, nil if they were not kept
+	states []S
+}
+
+// FindCycle steps from initial until a state repeats. States are compared by
+// their key, so the key has to tell states apart exactly, eg a string of the
+// whole state. All states up to the repetition are kept, step must return a
+// new state and not change the one it is given.
+func FindCycle[S any, K comparable](initial S, step func(S) S, key func(S) K) Cycle[S] {
+	seen := make(map[K]int)
+	states := []S{}
+	state := initial
+	for n := 0; ; n++ {
+		k := key(state)
+		if start, ok := seen[k]; ok {
+			return Cycle[S]{Start: start, Length: n - start, initial: initial, step: step, states: states}
+		}
+		seen[k] = n
+		states = append(states, state)
+		state = step(state)
+	}
+}
+
+// FindCycleBrent is FindCycle with Brent's algorithm, it only keeps a few
+// states at a time but steps about three times as often
+func FindCycleBrent[S any, K comparable](initial S, step func(S) S, key func(S) K) Cycle[S] {
+	// find the length, the hare runs ahead in growing powers of two
+	power, length := 1, 1
+	tortoise, hare := initial, step(initial)
+	for key(tortoise) != key(hare) {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		hare = step(hare)
+		length++
+	}
+
+	// find the start, the hare starts length steps ahead
+	tortoise, hare = initial, initial
+	for n := 0; n < length; n++ {
+		hare = step(hare)
+	}
+	start := 0
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		start++
+	}
+	return Cycle[S]{Start: start, Length: length, initial: initial, step: step}
+}
+
+// Index returns the number of steps up to Start+Length-1 that give the same
+// state as n steps, it panics if n is negative like a slice index does
+func (c Cycle[S]) Index(n int) int {
+	if n < 0 {
+		panic(fmt.Sprintf("cycle.Index() - negative step count %d", n))
+	}
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// At returns the state after n steps without doing them all, it panics if n
+// is negative
+func (c Cycle[S]) At(n int) S {
+	idx := c.Index(n)
+	if c.states != nil {
+		return c.states[idx]
+	}
+	state := c.initial
+	for ; idx > 0; idx-- {
+		state = c.step(state)
+	}
+	return state
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFindCycle(t *testing.T) {
+	// x -> x*x+1 modulo m runs into a cycle after a few steps
+	tests := []struct {
+		modulus int
+		initial int
+	}{
+		{255, 3},
+		{1000, 2},
+		{7, 0},
+		{1, 0},
+	}
+	for _, test := range tests {
+		step := func(x int) int { return (x*x + 1) % test.modulus }
+		identity := func(x int) int { return x }
+
+		// brute force the first repetition
+		first := map[int]int{}
+		x, n := test.initial, 0
+		for ; ; n++ {
+			if _, ok := first[x]; ok {
+				break
+			}
+			first[x] = n
+			x = step(x)
+		}
+		start, length := first[x], n-first[x]
+
+		for name, cycle := range map[string]Cycle[int]{
+			"map":   FindCycle(test.initial, step, identity),
+			"brent": FindCycleBrent(test.initial, step, identity),
+		} {
+			if cycle.Start != start || cycle.Length != length {
+				t.Errorf("%s %v: Expected start %d and length %d, but got %d and %d", name, test, start, length, cycle.Start, cycle.Length)
+			}
+
+			// the state after n steps is the same as stepping n times
+			x := test.initial
+			for n := 0; n < 3*(start+length); n++ {
+				if cycle.At(n) != x {
+					t.Errorf("%s %v: Expected state %d after %d steps, but got %d", name, test, x, n, cycle.At(n))
+				}
+				x = step(x)
+			}
+		}
+	}
+}
+
+func TestFindCycleJump(t *testing.T) {
+	// states are slices, the key tells them apart exactly
+	step := func(s []int) []int { return []int{s[1], (s[0] + s[1]) % 10} }
+	cycle := FindCycle([]int{0, 1}, step, func(s []int) string { return fmt.Sprint(s) })
+	if cycle.Start != 0 || cycle.Length != 60 {
+		t.Errorf("Expected the Pisano period 60, but got start %d and length %d", cycle.Start, cycle.Length)
+	}
+	// fib(10^9) modulo 10 is 5
+	if state := cycle.At(1000000000); state[0] != 5 {
+		t.Errorf("Expected 5, but got %d", state[0])
+	}
+}
+
+func TestCycleNegativeSteps(t *testing.T) {
+	step := func(x int) int { return (x*x + 1) % 255 }
+	key := func(x int) int { return x }
+	cycles := []Cycle[int]{FindCycle(3, step, key), FindCycleBrent(3, step, key)}
+
+	for _, cycle := range cycles {
+		for name, call := range map[string]func(){
+			"Index": func() { cycle.Index(-1) },
+			"At":    func() { cycle.At(-1) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Expected %s(-1) to panic", name)
+					}
+				}()
+				call()
+			}()
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LineFormat is a compiled line pattern like "%s = (%s, %s)" that fills typed
+// values, errors are ParseErrors with the column where the line differs.
+//
+//	%d  integer into *int, *int64, *uint64 or *float64
+//	%f  number into *float64
+//	%s  text up to the next literal into *string, into *Line to keep its
+//	    position for later errors, or a list separated by blanks or commas
+//	    into *[]string, *[]int or *[]uint64
+//	%%  a literal %
+//
+// Blanks in the pattern match one or more blanks, all other text has to match
+// exactly. Verbs have to be separated by text.
+type LineFormat struct {
+	pattern string
+	pieces  []piece
+}
+
+type piece struct {
+	verb    byte           // 0 for literal text
+	text    string         // literal text
+	anchor  *regexp.Regexp // literal text at the current position
+	search  *regexp.Regexp // literal text anywhere, ends the text of a %s before it
+	numbers *regexp.Regexp // number of a %d or %f verb
+}
+
+var integerPattern = regexp.MustCompile(`^[+-]?[0-9]+`)
+var floatPattern = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?`)
+var blanksPattern = regexp.MustCompile(`[ \t]+`)
+
+func NewLineFormat(pattern string) (*LineFormat, error) {
+	format := &LineFormat{pattern: pattern}
+	var literal strings.Builder
+
+	addLiteral := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		text := literal.String()
+		var expr strings.Builder
+		for idx, part := range blanksPattern.Split(text, -1) {
+			if idx > 0 {
+				expr.WriteString(`[ \t]+`)
+			}
+			expr.WriteString(regexp.QuoteMeta(part))
+		}
+		format.pieces = append(format.pieces, piece{
+			text:   text,
+			anchor: regexp.MustCompile("^" + expr.String()),
+			search: regexp.MustCompile(expr.String()),
+		})
+		literal.Reset()
+	}
+
+	for idx := 0; idx < len(pattern); idx++ {
+		if pattern[idx] != '%' {
+			literal.WriteByte(pattern[idx])
+			continue
+		}
+		if idx+1 == len(pattern) {
+			return nil, fmt.Errorf("format %q: missing verb at the end", pattern)
+		}
+		idx++
+		verb := pattern[idx]
+		switch verb {
+		case '%':
+			literal.WriteByte('%')
+			continue
+		case 'd', 'f', 's':
+		default:
+			return nil, fmt.Errorf("format %q: unknown verb %%%c", pattern, verb)
+		}
+
+		addLiteral()
+		if n := len(format.pieces); n > 0 && format.pieces[n-1].verb != 0 {
+			return nil, fmt.Errorf("format %q: verbs have to be separated by text", pattern)
+		}
+		p := piece{verb: verb}
+		switch verb {
+		case 'd':
+			p.numbers = integerPattern
+		case 'f':
+			p.numbers = floatPattern
+		}
+		format.pieces = append(format.pieces, p)
+	}
+	addLiteral()
+
+	return format, nil
+}
+
+// MustLineFormat is like NewLineFormat but panics on an invalid pattern, for package variables
+func MustLineFormat(pattern string) *LineFormat {
+	format, err := NewLineFormat(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return format
+}
+
+func (f *LineFormat) String() string {
+	return f.pattern
+}
+
+// Verbs returns the number of values a line of this format has
+func (f *LineFormat) Verbs() int {
+	count := 0
+	for _, p := range f.pieces {
+		if p.verb != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// text and offset of a verb in the line
+type capture struct {
+	verb   byte
+	text   string
+	offset int
+}
+
+// matches the line piece by piece so the error can tell where it differs
+func (f *LineFormat) match(line Line) ([]capture, error) {
+	var captures []capture
+	text := line.Text
+	offset := 0
+
+	for idx, p := range f.pieces {
+		rest := text[offset:]
+		switch p.verb {
+		case 0:
+			loc := p.anchor.FindStringIndex(rest)
+			if loc == nil {
+				return nil, line.ErrorfAt(offset, "expected %q", p.text)
+			}
+			offset += loc[1]
+		case 'd', 'f':
+			loc := p.numbers.FindStringIndex(rest)
+			if loc == nil {
+				return nil, line.ErrorfAt(offset, "expected a number")
+			}
+			captures = append(captures, capture{verb: p.verb, text: rest[:loc[1]], offset: offset})
+			offset += loc[1]
+		case 's':
+			end := len(rest)
+			if idx+1 < len(f.pieces) {
+				next := f.pieces[idx+1]
+				loc := next.search.FindStringIndex(rest)
+				if loc == nil {
+					return nil, line.ErrorfAt(offset, "expected text followed by %q", next.text)
+				}
+				end = loc[0]
+			}
+			if end == 0 {
+				return nil, line.ErrorfAt(offset, "expected text")
+			}
+			captures = append(captures, capture{verb: p.verb, text: rest[:end], offset: offset})
+			offset += end
+		}
+	}
+
+	if offset != len(text) {
+		return nil, line.ErrorfAt(offset, "unexpected %q at the end", text[offset:])
+	}
+	return captures, nil
+}
+
+// Scan matches the line and stores the values in targets, one pointer per verb
+func (f *LineFormat) Scan(line Line, targets ...any) error {
+	captures, err := f.match(line)
+	if err != nil {
+		return err
+	}
+	if len(targets) != len(captures) {
+		return fmt.Errorf("format %q: %d verbs but %d targets", f.pattern, len(captures), len(targets))
+	}
+	for idx, c := range captures {
+		if err := assign(line, c, targets[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func assign(line Line, c capture, target any) error {
+	atColumn := func(err error) error {
+		return line.ErrorfAt(c.offset, "%v", err)
+	}
+
+	switch t := target.(type) {
+	case *string:
+		if c.verb != 's' {
+			break
+		}
+		*t = c.text
+		return nil
+	case *Line:
+		if c.verb != 's' {
+			break
+		}
+		*t = line.Slice(c.offset, c.offset+len(c.text))
+		return nil
+	case *int:
+		if c.verb != 'd' {
+			break
+		}
+		i, err := StringToInt(c.text)
+		if err != nil {
+			return atColumn(err)
+		}
+		*t = i
+		return nil
+	case *int64:
+		if c.verb != 'd' {
+			break
+		}
+		i, err := strconv.ParseInt(c.text, 10, 64)
+		if err != nil {
+			return atColumn(numError(err))
+		}
+		*t = i
+		return nil
+	case *uint64:
+		if c.verb != 'd' {
+			break
+		}
+		i, err := StringToUint64(c.text)
+		if err != nil {
+			return atColumn(err)
+		}
+		*t = i
+		return nil
+	case *float64:
+		if c.verb == 's' {
+			break
+		}
+		value, err := strconv.ParseFloat(c.text, 64)
+		if err != nil {
+			return atColumn(numError(err))
+		}
+		*t = value
+		return nil
+	case *[]string, *[]int, *[]uint64:
+		if c.verb != 's' {
+			break
+		}
+		return assignList(line, c, target)
+	}
+	return fmt.Errorf("verb %%%c can not be stored in %T", c.verb, target)
+}
+
+// splits a %s capture at blanks and commas, each element is converted on its own
+func assignList(line Line, c capture, target any) error {
+	var fields []string
+	var offsets []int
+	start := -1
+	for idx := 0; idx <= len(c.text); idx++ {
+		separator := idx == len(c.text) || c.text[idx] == ' ' || c.text[idx] == '\t' || c.text[idx] == ','
+		if separator && start >= 0 {
+			fields = append(fields, c.text[start:idx])
+			offsets = append(offsets, c.offset+start)
+			start = -1
+		} else if !separator && start < 0 {
+			start = idx
+		}
+	}
+
+	switch t := target.(type) {
+	case *[]string:
+		*t = fields
+	case *[]int:
+		*t = make([]int, len(fields))
+		for idx, field := range fields {
+			i, err := StringToInt(field)
+			if err != nil {
+				return line.ErrorfAt(offsets[idx], "%v", err)
+			}
+			(*t)[idx] = i
+		}
+	case *[]uint64:
+		*t = make([]uint64, len(fields))
+		for idx, field := range fields {
+			i, err := StringToUint64(field)
+			if err != nil {
+				return line.ErrorfAt(offsets[idx], "%v", err)
+			}
+			(*t)[idx] = i
+		}
+	}
+	return nil
+}
+
+// -------------------------- Struct tags ------------------------------------
+
+// formats of struct types, built once from their tags
+var structFormats sync.Map
+
+// ScanStruct fills the fields of the struct target points to. Each field to
+// fill has a parse tag with its part of the line, the tags in field order
+// form the format:
+//
+//	type Transition struct {
+//		Position string `parse:"%s = ("`
+//		Left     string `parse:"%s, "`
+//		Right    string `parse:"%s)"`
+//	}
+//
+// Text before the first verb goes into the tag of the first field.
+func ScanStruct(line Line, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ScanStruct() needs a pointer to a struct, got %T", target)
+	}
+	value = value.Elem()
+
+	format, fields, err := structFormat(value.Type())
+	if err != nil {
+		return err
+	}
+	targets := make([]any, len(fields))
+	for idx, field := range fields {
+		targets[idx] = value.Field(field).Addr().Interface()
+	}
+	return format.Scan(line, targets...)
+}
+
+type cachedFormat struct {
+	format *LineFormat
+	fields []int
+	err    error
+}
+
+func structFormat(structType reflect.Type) (*LineFormat, []int, error) {
+	if cached, ok := structFormats.Load(structType); ok {
+		c := cached.(cachedFormat)
+		return c.format, c.fields, c.err
+	}
+
+	var pattern strings.Builder
+	var fields []int
+	var err error
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		tag, ok := field.Tag.Lookup("parse")
+		if !ok {
+			continue
+		}
+		// reflect can not set unexported fields
+		if !field.IsExported() && err == nil {
+			err = fmt.Errorf("%v: field %s has a parse tag but is not exported", structType, field.Name)
+		}
+		pattern.WriteString(tag)
+		fields = append(fields, idx)
+	}
+
+	c := cachedFormat{fields: fields, err: err}
+	if c.err == nil {
+		c.format, c.err = NewLineFormat(pattern.String())
+	}
+	if c.err == nil && c.format.Verbs() != len(fields) {
+		c.err = fmt.Errorf("%v: each parse tag needs exactly one verb", structType)
+	}
+	structFormats.Store(structType, c)
+	return c.format, c.fields, c.err
+}
+
+// ScanLines parses every line of the input into a struct with parse tags
+func ScanLines[T any](input []string) ([]T, error) {
+	result := make([]T, len(input))
+	for idx, text := range input {
+		if err := ScanStruct(NewLine(idx, text), &result[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
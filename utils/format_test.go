@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineFormatScan(t *testing.T) {
+	format := MustLineFormat("%f, %f @ %d, %d")
+	var x, y float64
+	var dx int
+	var dy int64
+	if err := format.Scan(NewLine(0, "19, 13 @ -2,  1"), &x, &y, &dx, &dy); err != nil {
+		t.Fatal(err)
+	}
+	if x != 19 || y != 13 || dx != -2 || dy != 1 {
+		t.Errorf("Expected 19 13 -2 1, but got %v %v %v %v", x, y, dx, dy)
+	}
+}
+
+func TestLineFormatErrors(t *testing.T) {
+	var text string
+	var number int
+	var list []int
+	tests := []struct {
+		pattern string
+		line    string
+		targets []any
+		column  int
+	}{
+		{"%s = (%s, %s)", "AAA = (BBB CCC)", []any{&text, &text, &text}, 8},
+		{"%s = (%s, %s)", "AAA = (BBB, CCC", []any{&text, &text, &text}, 13},
+		{"%d,%d,%d~%d,%d,%d", "1,0,1~1,x,1", []any{&number, &number, &number, &number, &number, &number}, 9},
+		{"%d %s", "6red", []any{&number, &text}, 2},
+		{"Card %d: %s | %s", "Card 1: 41 4x | 83", []any{&number, &list, &list}, 12},
+		{"Card %d: %s", "Card 99999999999999999999: 1", []any{&number, &list}, 6},
+	}
+
+	for _, test := range tests {
+		err := MustLineFormat(test.pattern).Scan(NewLine(4, test.line), test.targets...)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q on %q: expected a ParseError, but got %v", test.pattern, test.line, err)
+			continue
+		}
+		if parseErr.Line != 5 || parseErr.Column != test.column {
+			t.Errorf("%q on %q: expected line 5, column %d, but got %v", test.pattern, test.line, test.column, err)
+		}
+	}
+}
+
+func TestNewLineFormatInvalid(t *testing.T) {
+	for _, pattern := range []string{"%d%d", "%x", "abc %"} {
+		if _, err := NewLineFormat(pattern); err == nil {
+			t.Errorf("Expected an error for pattern %q", pattern)
+		}
+	}
+}
+
+type testTransition struct {
+	Position string `parse:"%s = ("`
+	Left     string `parse:"%s, "`
+	Right    string `parse:"%s)"`
+	visited  bool
+}
+
+func TestScanLines(t *testing.T) {
+	input := []string{"AAA = (BBB, CCC)", "BBB = (DDD, EEE)"}
+	expected := []testTransition{{"AAA", "BBB", "CCC", false}, {"BBB", "DDD", "EEE", false}}
+
+	result, err := ScanLines[testTransition](input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestScanStructList(t *testing.T) {
+	var ticket struct {
+		ID    int   `parse:"Card %d: "`
+		Lucky []int `parse:"%s | "`
+		Drawn []int `parse:"%s"`
+	}
+	if err := ScanStruct(NewLine(0, "Card   3:  1 21 | 59  5"), &ticket); err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ID != 3 || !reflect.DeepEqual(ticket.Lucky, []int{1, 21}) || !reflect.DeepEqual(ticket.Drawn, []int{59, 5}) {
+		t.Errorf("Expected card 3 with [1 21] | [59 5], but got %+v", ticket)
+	}
+}
+
+func TestScanStructLine(t *testing.T) {
+	var transition struct {
+		Position string `parse:"%s = ("`
+		Left     Line   `parse:"%s, "`
+		Right    Line   `parse:"%s)"`
+	}
+	if err := ScanStruct(Line{Index: 4, Text: "AAA = (BBB,  CCC)", Offset: 2}, &transition); err != nil {
+		t.Fatal(err)
+	}
+	if transition.Left != (Line{Index: 4, Text: "BBB", Offset: 9}) || transition.Right != (Line{Index: 4, Text: "CCC", Offset: 15}) {
+		t.Errorf("Expected BBB at 9 and CCC at 15, but got %+v", transition)
+	}
+}
+
+func TestScanStructUnexported(t *testing.T) {
+	var ticket struct {
+		ID    int   `parse:"Card %d: "`
+		lucky []int `parse:"%s"`
+	}
+	err := ScanStruct(NewLine(0, "Card 3: 1 21"), &ticket)
+	if err == nil || !strings.Contains(err.Error(), "field lucky has a parse tag but is not exported") {
+		t.Errorf("Expected an error for the unexported field, but got %v", err)
+	}
+	if ticket.lucky != nil {
+		t.Errorf("Expected lucky untouched, but got %v", ticket.lucky)
+	}
+}
+
+func FuzzLineFormatScan(f *testing.F) {
+	format := MustLineFormat("%s %d, %f @ %d")
+	f.Add("one 19, 13.5 @ -2")
+	f.Add("x -1, 1e3 @ 0")
+	f.Fuzz(func(t *testing.T, text string) {
+		var name string
+		var x, dy int
+		var y float64
+		err := format.Scan(NewLine(0, text), &name, &x, &y, &dy)
+		var parseErr *ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			t.Errorf("%q: expected a ParseError, but got %v", text, err)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package geometry
+
+import (
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// Box2 is the axis aligned rectangle of the integer points from Min to Max,
+// both included
+type Box2 struct {
+	Min Point2
+	Max Point2
+}
+
+func (b Box2) X() utils.Interval {
+	return utils.NewInterval(b.Min.X, b.Max.X)
+}
+
+func (b Box2) Y() utils.Interval {
+	return utils.NewInterval(b.Min.Y, b.Max.Y)
+}
+
+// Empty is true if Min is beyond Max on any axis
+func (b Box2) Empty() bool {
+	return b.X().Empty() || b.Y().Empty()
+}
+
+func (b Box2) Contains(p Point2) bool {
+	return b.X().Contains(p.X) && b.Y().Contains(p.Y)
+}
+
+// Overlaps is true if the boxes share a point
+func (b Box2) Overlaps(o Box2) bool {
+	return b.X().Overlaps(o.X()) && b.Y().Overlaps(o.Y())
+}
+
+// Box3 is the axis aligned box of the integer points from Min to Max, both
+// included
+type Box3 struct {
+	Min Point3
+	Max Point3
+}
+
+func (b Box3) X() utils.Interval {
+	return utils.NewInterval(b.Min.X, b.Max.X)
+}
+
+func (b Box3) Y() utils.Interval {
+	return utils.NewInterval(b.Min.Y, b.Max.Y)
+}
+
+func (b Box3) Z() utils.Interval {
+	return utils.NewInterval(b.Min.Z, b.Max.Z)
+}
+
+// Empty is true if Min is beyond Max on any axis
+func (b Box3) Empty() bool {
+	return b.X().Empty() || b.Y().Empty() || b.Z().Empty()
+}
+
+func (b Box3) Contains(p Point3) bool {
+	return b.X().Contains(p.X) && b.Y().Contains(p.Y) && b.Z().Contains(p.Z)
+}
+
+// Overlaps is true if the boxes share a point
+func (b Box3) Overlaps(o Box3) bool {
+	return b.X().Overlaps(o.X()) && b.Y().Overlaps(o.Y()) && b.Z().Overlaps(o.Z())
+}
+
+// Shift moves the box by v
+func (b Box3) Shift(v Point3) Box3 {
+	return Box3{Min: b.Min.Add(v), Max: b.Max.Add(v)}
+}
+
+// XY is the box seen from above
+func (b Box3) XY() Box2 {
+	return Box2{Min: b.Min.XY(), Max: b.Max.XY()}
+}
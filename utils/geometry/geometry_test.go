@@ -0,0 +1,113 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoints(t *testing.T) {
+	p := Point2{X: 3, Y: 4}
+	if p.Sub(Point2{X: 1, Y: 1}).Add(Point2{X: 0, Y: 2}).Scale(2) != (Point2{X: 4, Y: 10}) {
+		t.Errorf("Expected (4,10), but got %v", p.Sub(Point2{X: 1, Y: 1}).Add(Point2{X: 0, Y: 2}).Scale(2))
+	}
+	if p.Dot(Point2{X: 1, Y: 2}) != 11 || p.Cross(Point2{X: 1, Y: 2}) != 2 || p.Manhattan(Point2{}) != 7 {
+		t.Errorf("Expected dot 11, cross 2 and distance 7")
+	}
+
+	x, y := Point3{X: 1}, Point3{Y: 1}
+	if x.Cross(y) != (Point3{Z: 1}) || x.Dot(y) != 0 {
+		t.Errorf("Expected x cross y to be z, but got %v", x.Cross(y))
+	}
+}
+
+func TestLineIntersect(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Line2
+		kind     Kind
+		expected string
+	}{
+		{"crossing", Line2{Point2{0, 0}, Point2{1, 1}}, Line2{Point2{0, 2}, Point2{1, -1}}, Crossing, "(1,1)"},
+		{"rational", Line2{Point2{0, 0}, Point2{2, 1}}, Line2{Point2{0, 1}, Point2{1, 0}}, Crossing, "(2,1)"},
+		{"fraction", Line2{Point2{0, 0}, Point2{3, 1}}, Line2{Point2{1, 0}, Point2{0, 1}}, Crossing, "(1,1/3)"},
+		{"vertical", Line2{Point2{5, 0}, Point2{0, 1}}, Line2{Point2{0, 3}, Point2{1, 0}}, Crossing, "(5,3)"},
+		{"parallel", Line2{Point2{0, 0}, Point2{1, 2}}, Line2{Point2{1, 0}, Point2{-2, -4}}, None, ""},
+		{"collinear", Line2{Point2{0, 0}, Point2{1, 2}}, Line2{Point2{2, 4}, Point2{-1, -2}}, Collinear, ""},
+		// beyond day 24 sized coordinates the cross products overflow an int
+		{"large", Line2{Point2{-570000000000000000, 1190000000000000000}, Point2{97, -89}},
+			Line2{Point2{-430000000000000000, 1310000000000000000}, Point2{-83, 101}}, Crossing, "(400000000000000000,300000000000000000)"},
+		{"large parallel", Line2{Point2{-570000000000000000, 1190000000000000000}, Point2{97, -89}},
+			Line2{Point2{-430000000000000000, 1310000000000000000}, Point2{-97, 89}}, None, ""},
+	}
+	for _, test := range tests {
+		result := test.a.Intersect(test.b)
+		if result.Kind != test.kind || (test.kind == Crossing && result.Point.String() != test.expected) {
+			t.Errorf("%s: Expected %d at %s, but got %d at %v", test.name, test.kind, test.expected, result.Kind, result.Point)
+		}
+		if result.Kind == Crossing {
+			// the point is on both lines
+			onB := test.b.Origin.Rat().Add(test.b.Direction.Rat().Scale(result.S))
+			if !onB.Equal(result.Point) {
+				t.Errorf("%s: Expected %v on the second line, but got %v", test.name, result.Point, onB)
+			}
+		}
+	}
+}
+
+func TestRayIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Ray2
+		kind Kind
+	}{
+		{"ahead", Ray2{Point2{0, 0}, Point2{1, 1}}, Ray2{Point2{0, 2}, Point2{1, -1}}, Crossing},
+		{"behind first", Ray2{Point2{2, 2}, Point2{1, 1}}, Ray2{Point2{0, 2}, Point2{1, -1}}, None},
+		{"behind second", Ray2{Point2{0, 0}, Point2{1, 1}}, Ray2{Point2{2, 0}, Point2{1, -1}}, None},
+		{"at origin", Ray2{Point2{1, 1}, Point2{1, 1}}, Ray2{Point2{0, 2}, Point2{1, -1}}, Crossing},
+		{"collinear towards", Ray2{Point2{0, 0}, Point2{1, 0}}, Ray2{Point2{5, 0}, Point2{-1, 0}}, Collinear},
+		{"collinear away", Ray2{Point2{0, 0}, Point2{-1, 0}}, Ray2{Point2{5, 0}, Point2{1, 0}}, None},
+		{"collinear same way", Ray2{Point2{0, 0}, Point2{-1, 0}}, Ray2{Point2{5, 0}, Point2{-2, 0}}, Collinear},
+	}
+	for _, test := range tests {
+		if result := test.a.Intersect(test.b); result.Kind != test.kind {
+			t.Errorf("%s: Expected %d, but got %d", test.name, test.kind, result.Kind)
+		}
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	// a 4x3 rectangle and a triangle with a sloped edge
+	rectangle := Polygon{{0, 0}, {4, 0}, {4, 3}, {0, 3}}
+	if rectangle.Area().String() != "12" || rectangle.Perimeter() != 14 || rectangle.BoundaryPoints() != 14 {
+		t.Errorf("Expected area 12 and perimeter 14, but got %v and %f", rectangle.Area(), rectangle.Perimeter())
+	}
+	if rectangle.InteriorPoints() != 6 || rectangle.LatticePoints() != 20 {
+		t.Errorf("Expected 6 points inside and 20 in total, but got %d and %d", rectangle.InteriorPoints(), rectangle.LatticePoints())
+	}
+
+	// clockwise corners give the same area
+	triangle := Polygon{{0, 0}, {0, 3}, {3, 0}}
+	if triangle.Area().String() != "9/2" || triangle.BoundaryPoints() != 9 || triangle.InteriorPoints() != 1 {
+		t.Errorf("Expected area 9/2 with 9 boundary and 1 interior point, but got %v, %d, %d", triangle.Area(), triangle.BoundaryPoints(), triangle.InteriorPoints())
+	}
+	if math.Abs(triangle.Perimeter()-(6+3*math.Sqrt2)) > 1e-9 {
+		t.Errorf("Expected perimeter %f, but got %f", 6+3*math.Sqrt2, triangle.Perimeter())
+	}
+}
+
+func TestBoxes(t *testing.T) {
+	a := Box3{Min: Point3{0, 0, 1}, Max: Point3{2, 0, 1}}
+	b := Box3{Min: Point3{2, 0, 2}, Max: Point3{2, 2, 2}}
+	if a.Overlaps(b) || !a.XY().Overlaps(b.XY()) {
+		t.Errorf("Expected %v and %v to overlap only seen from above", a, b)
+	}
+	if !a.Overlaps(b.Shift(Point3{Z: -1})) || !a.Contains(Point3{1, 0, 1}) {
+		t.Errorf("Expected boxes to overlap after the fall")
+	}
+	if !(Box3{Min: Point3{1, 0, 0}, Max: Point3{0, 0, 0}}).Empty() || a.Empty() {
+		t.Errorf("Expected only a box with min beyond max to be empty")
+	}
+	if (Box2{Min: Point2{0, 0}, Max: Point2{1, 1}}).Contains(Point2{2, 1}) {
+		t.Errorf("Expected (2,1) outside")
+	}
+}
@@ -0,0 +1,82 @@
+package geometry
+
+import (
+	"github.com/cdr74/AdventOfCode2023/utils/number"
+)
+
+// Line2 is the line through Origin in Direction, the points Origin + t*Direction
+type Line2 struct {
+	Origin    Point2
+	Direction Point2
+}
+
+// Ray2 is the half of a line from Origin on, t is not negative
+type Ray2 struct {
+	Origin    Point2
+	Direction Point2
+}
+
+// Kind tells how two lines or rays meet
+type Kind int
+
+const (
+	// None: they are parallel or, for rays, cross behind an origin
+	None Kind = iota
+	// Crossing: they meet in exactly one point
+	Crossing
+	// Collinear: they lie on the same line and share more than one point
+	Collinear
+)
+
+// Intersection is where two lines or rays meet. For a Crossing Point is
+// l.Origin + T*l.Direction = o.Origin + S*o.Direction.
+type Intersection struct {
+	Kind  Kind
+	Point RatPoint2
+	T     number.Rat
+	S     number.Rat
+}
+
+// Intersect solves l.Origin + t*l.Direction = o.Origin + s*o.Direction with
+// Cramer's rule, all in exact numbers as the products of the coordinates
+// overflow an int, eg for the hailstones of day 24
+func (l Line2) Intersect(o Line2) Intersection {
+	direction, other := l.Direction.Rat(), o.Direction.Rat()
+	d := direction.Cross(other)
+	delta := o.Origin.Rat().Sub(l.Origin.Rat())
+	if d.IsZero() {
+		// parallel, the same line if the origins are on one line too
+		if delta.Cross(direction).IsZero() {
+			return Intersection{Kind: Collinear}
+		}
+		return Intersection{Kind: None}
+	}
+
+	t := delta.Cross(other).Div(d)
+	s := delta.Cross(direction).Div(d)
+	point := l.Origin.Rat().Add(direction.Scale(t))
+	return Intersection{Kind: Crossing, Point: point, T: t, S: s}
+}
+
+func (r Ray2) Line() Line2 {
+	return Line2(r)
+}
+
+// Intersect is Line2.Intersect for the rays, crossings have T and S of 0 or
+// more. Collinear rays meet if either origin is on the other ray.
+func (r Ray2) Intersect(o Ray2) Intersection {
+	result := r.Line().Intersect(o.Line())
+	switch result.Kind {
+	case Crossing:
+		if result.T.Sign() < 0 || result.S.Sign() < 0 {
+			return Intersection{Kind: None}
+		}
+	case Collinear:
+		delta := o.Origin.Rat().Sub(r.Origin.Rat())
+		if delta.Dot(r.Direction.Rat()).Sign() < 0 && delta.Dot(o.Direction.Rat()).Sign() > 0 {
+			// pointing away from each other
+			return Intersection{Kind: None}
+		}
+	}
+	return result
+}
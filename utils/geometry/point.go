@@ -0,0 +1,126 @@
+// Package geometry has integer points in 2D and 3D, exact intersections of
+// lines and rays, lattice polygons and axis aligned boxes. Points double as
+// vectors, Sub of two points is the vector between them.
+package geometry
+
+import (
+	"fmt"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/number"
+)
+
+// Point2 is a point or vector in the plane
+type Point2 struct {
+	X int
+	Y int
+}
+
+func (p Point2) Add(o Point2) Point2 {
+	return Point2{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+func (p Point2) Sub(o Point2) Point2 {
+	return Point2{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+func (p Point2) Scale(factor int) Point2 {
+	return Point2{X: p.X * factor, Y: p.Y * factor}
+}
+
+func (p Point2) Dot(o Point2) int {
+	return p.X*o.X + p.Y*o.Y
+}
+
+// Cross is the z of the 3D cross product, positive if o is counter clockwise of p
+func (p Point2) Cross(o Point2) int {
+	return p.X*o.Y - p.Y*o.X
+}
+
+func (p Point2) Manhattan(o Point2) int {
+	return utils.Abs(p.X-o.X) + utils.Abs(p.Y-o.Y)
+}
+
+// Rat returns the point with exact coordinates
+func (p Point2) Rat() RatPoint2 {
+	return RatPoint2{X: number.RatOf(p.X), Y: number.RatOf(p.Y)}
+}
+
+func (p Point2) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// Point3 is a point or vector in space
+type Point3 struct {
+	X int
+	Y int
+	Z int
+}
+
+func (p Point3) Add(o Point3) Point3 {
+	return Point3{X: p.X + o.X, Y: p.Y + o.Y, Z: p.Z + o.Z}
+}
+
+func (p Point3) Sub(o Point3) Point3 {
+	return Point3{X: p.X - o.X, Y: p.Y - o.Y, Z: p.Z - o.Z}
+}
+
+func (p Point3) Scale(factor int) Point3 {
+	return Point3{X: p.X * factor, Y: p.Y * factor, Z: p.Z * factor}
+}
+
+func (p Point3) Dot(o Point3) int {
+	return p.X*o.X + p.Y*o.Y + p.Z*o.Z
+}
+
+func (p Point3) Cross(o Point3) Point3 {
+	return Point3{X: p.Y*o.Z - p.Z*o.Y, Y: p.Z*o.X - p.X*o.Z, Z: p.X*o.Y - p.Y*o.X}
+}
+
+func (p Point3) Manhattan(o Point3) int {
+	return utils.Abs(p.X-o.X) + utils.Abs(p.Y-o.Y) + utils.Abs(p.Z-o.Z)
+}
+
+// XY drops z
+func (p Point3) XY() Point2 {
+	return Point2{X: p.X, Y: p.Y}
+}
+
+func (p Point3) String() string {
+	return fmt.Sprintf("(%d,%d,%d)", p.X, p.Y, p.Z)
+}
+
+// RatPoint2 is a point or vector with exact rational coordinates, eg where
+// two lines of integer points cross
+type RatPoint2 struct {
+	X number.Rat
+	Y number.Rat
+}
+
+func (p RatPoint2) Add(o RatPoint2) RatPoint2 {
+	return RatPoint2{X: p.X.Add(o.X), Y: p.Y.Add(o.Y)}
+}
+
+func (p RatPoint2) Sub(o RatPoint2) RatPoint2 {
+	return RatPoint2{X: p.X.Sub(o.X), Y: p.Y.Sub(o.Y)}
+}
+
+func (p RatPoint2) Scale(factor number.Rat) RatPoint2 {
+	return RatPoint2{X: p.X.Mul(factor), Y: p.Y.Mul(factor)}
+}
+
+func (p RatPoint2) Dot(o RatPoint2) number.Rat {
+	return p.X.Mul(o.X).Add(p.Y.Mul(o.Y))
+}
+
+func (p RatPoint2) Cross(o RatPoint2) number.Rat {
+	return p.X.Mul(o.Y).Sub(p.Y.Mul(o.X))
+}
+
+func (p RatPoint2) Equal(o RatPoint2) bool {
+	return p.X.Cmp(o.X) == 0 && p.Y.Cmp(o.Y) == 0
+}
+
+func (p RatPoint2) String() string {
+	return fmt.Sprintf("(%v,%v)", p.X, p.Y)
+}
@@ -0,0 +1,74 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/cdr74/AdventOfCode2023/utils/number"
+)
+
+// Polygon is the closed path through its corners, the last corner connects
+// back to the first one
+type Polygon []Point2
+
+// edges calls fn for each edge from a to b
+func (p Polygon) edges(fn func(a, b Point2)) {
+	for idx := range p {
+		fn(p[idx], p[(idx+1)%len(p)])
+	}
+}
+
+// Area with the shoelace formula, computed exactly as the corner products
+// get large. The area of integer corners is a multiple of 1/2.
+func (p Polygon) Area() number.Rat {
+	var area number.Rat
+	p.edges(func(a, b Point2) {
+		area = area.Add(number.RatOf(a.X).Mul(number.RatOf(b.Y))).Sub(number.RatOf(a.Y).Mul(number.RatOf(b.X)))
+	})
+	return area.Abs().Div(number.RatOf(2))
+}
+
+// Perimeter is the length of all edges
+func (p Polygon) Perimeter() float64 {
+	length := 0.0
+	p.edges(func(a, b Point2) {
+		d := b.Sub(a)
+		length += math.Hypot(float64(d.X), float64(d.Y))
+	})
+	return length
+}
+
+// BoundaryPoints counts the integer points on the edges, it is the
+// perimeter for polygons with only horizontal and vertical edges
+func (p Polygon) BoundaryPoints() int {
+	count := 0
+	p.edges(func(a, b Point2) {
+		d := b.Sub(a)
+		gcd, err := number.Gcd(d.X, d.Y)
+		if err != nil {
+			panic("geometry: " + err.Error())
+		}
+		count += gcd
+	})
+	return count
+}
+
+// InteriorPoints counts the integer points strictly inside with Pick's
+// theorem, area = interior + boundary/2 - 1
+func (p Polygon) InteriorPoints() int {
+	interior := p.Area().Sub(number.NewRat(p.BoundaryPoints(), 2)).Add(number.RatOf(1))
+	return mustInt(interior)
+}
+
+// LatticePoints counts the integer points inside or on the edges, eg the
+// cubes of a trench dug along the polygon and its inside
+func (p Polygon) LatticePoints() int {
+	return p.InteriorPoints() + p.BoundaryPoints()
+}
+
+func mustInt(r number.Rat) int {
+	result, err := number.Int[int](r)
+	if err != nil {
+		panic("geometry: " + r.String() + " does not fit into int")
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the graph in the DOT language of graphviz, eg to render it
+// with "dot -Tsvg". Edges show their label or a weight other than 1.
+func (g *Graph[K]) WriteDOT(w io.Writer, name string) error {
+	kind, arrow := "graph", "--"
+	if g.directed {
+		kind, arrow = "digraph", "->"
+	}
+	if _, err := fmt.Fprintf(w, "%s %s {\n", kind, strconv.Quote(name)); err != nil {
+		return err
+	}
+
+	for _, from := range g.nodes {
+		if len(g.edges[from]) == 0 {
+			if _, err := fmt.Fprintf(w, "\t%s;\n", dotID(from)); err != nil {
+				return err
+			}
+		}
+		for _, edge := range g.edges[from] {
+			// undirected edges are stored both ways, write them once
+			if !g.directed && g.index[edge.To] < g.index[from] {
+				continue
+			}
+			attributes := ""
+			if edge.Label != "" {
+				attributes = fmt.Sprintf(" [label=%s]", strconv.Quote(edge.Label))
+			} else if edge.Weight != 1 {
+				attributes = fmt.Sprintf(" [label=%d]", edge.Weight)
+			}
+			if _, err := fmt.Fprintf(w, "\t%s %s %s%s;\n", dotID(from), arrow, dotID(edge.To), attributes); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func dotID(node any) string {
+	return strconv.Quote(fmt.Sprint(node))
+}
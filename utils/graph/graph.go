@@ -0,0 +1,154 @@
+// Package graph is a generic graph of comparable node IDs with directed or
+// undirected, weighted and optionally labelled edges.
+package graph
+
+import (
+	"fmt"
+)
+
+// Edge leads to node To, edges added without a weight have a weight of 1
+type Edge[K comparable] struct {
+	To     K
+	Weight int
+	Label  string
+}
+
+// Graph keeps nodes and the edges of each node in the order they were added
+type Graph[K comparable] struct {
+	directed bool
+	nodes    []K
+	index    map[K]int
+	edges    map[K][]Edge[K]
+}
+
+func NewDirected[K comparable]() *Graph[K] {
+	return &Graph[K]{directed: true, index: make(map[K]int), edges: make(map[K][]Edge[K])}
+}
+
+// NewUndirected returns a graph where each edge can be passed both ways
+func NewUndirected[K comparable]() *Graph[K] {
+	return &Graph[K]{directed: false, index: make(map[K]int), edges: make(map[K][]Edge[K])}
+}
+
+func (g *Graph[K]) Directed() bool {
+	return g.directed
+}
+
+// AddNode adds a node without edges, adding a node twice has no effect
+func (g *Graph[K]) AddNode(node K) {
+	if _, ok := g.index[node]; !ok {
+		g.index[node] = len(g.nodes)
+		g.nodes = append(g.nodes, node)
+	}
+}
+
+// AddEdge adds an edge of weight 1, the nodes are added if missing
+func (g *Graph[K]) AddEdge(from K, to K) {
+	g.addEdge(from, Edge[K]{To: to, Weight: 1})
+}
+
+func (g *Graph[K]) AddWeightedEdge(from K, to K, weight int) {
+	g.addEdge(from, Edge[K]{To: to, Weight: weight})
+}
+
+// AddLabeledEdge adds an edge of weight 1 that can be found by its label with Next
+func (g *Graph[K]) AddLabeledEdge(from K, to K, label string) {
+	g.addEdge(from, Edge[K]{To: to, Weight: 1, Label: label})
+}
+
+func (g *Graph[K]) addEdge(from K, edge Edge[K]) {
+	g.addArc(from, edge)
+	if !g.directed && from != edge.To {
+		g.addArc(edge.To, Edge[K]{To: from, Weight: edge.Weight, Label: edge.Label})
+	}
+}
+
+// adds the edge in one direction only
+func (g *Graph[K]) addArc(from K, edge Edge[K]) {
+	g.AddNode(from)
+	g.AddNode(edge.To)
+	g.edges[from] = append(g.edges[from], edge)
+}
+
+func (g *Graph[K]) HasNode(node K) bool {
+	_, ok := g.index[node]
+	return ok
+}
+
+// Nodes returns all nodes in the order they were added
+func (g *Graph[K]) Nodes() []K {
+	return g.nodes
+}
+
+func (g *Graph[K]) Len() int {
+	return len(g.nodes)
+}
+
+// Edges returns the edges leaving node in the order they were added
+func (g *Graph[K]) Edges(node K) []Edge[K] {
+	return g.edges[node]
+}
+
+// Neighbors returns the nodes the edges of node lead to
+func (g *Graph[K]) Neighbors(node K) []K {
+	edges := g.edges[node]
+	result := make([]K, len(edges))
+	for idx, edge := range edges {
+		result[idx] = edge.To
+	}
+	return result
+}
+
+// Predecessors returns the nodes with an edge to node in the order of Nodes,
+// for undirected graphs these are the neighbours
+func (g *Graph[K]) Predecessors(node K) []K {
+	var result []K
+	for _, from := range g.nodes {
+		for _, edge := range g.edges[from] {
+			if edge.To == node {
+				result = append(result, from)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Next follows the first edge of node with the given label
+func (g *Graph[K]) Next(node K, label string) (K, bool) {
+	for _, edge := range g.edges[node] {
+		if edge.Label == label {
+			return edge.To, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+func (g *Graph[K]) String() string {
+	kind := "undirected"
+	if g.directed {
+		kind = "directed"
+	}
+	edges := 0
+	for _, e := range g.edges {
+		edges += len(e)
+	}
+	if !g.directed {
+		edges = (edges + g.loops()) / 2
+	}
+	return fmt.Sprintf("%s graph, %d nodes, %d edges", kind, len(g.nodes), edges)
+}
+
+// number of edges from a node to itself, these are stored once in undirected graphs
+func (g *Graph[K]) loops() int {
+	count := 0
+	for from, edges := range g.edges {
+		for _, edge := range edges {
+			if edge.To == from {
+				count++
+			}
+		}
+	}
+	return count
+}
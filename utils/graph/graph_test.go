@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// a -> b -> d, a -> c -> d, e alone
+func diamond() *Graph[string] {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	g.AddNode("e")
+	return g
+}
+
+func TestEdges(t *testing.T) {
+	g := diamond()
+	if !reflect.DeepEqual(g.Nodes(), []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Expected nodes in order of adding, but got %v", g.Nodes())
+	}
+	if !reflect.DeepEqual(g.Neighbors("a"), []string{"b", "c"}) {
+		t.Errorf("Expected b c, but got %v", g.Neighbors("a"))
+	}
+	if !reflect.DeepEqual(g.Predecessors("d"), []string{"b", "c"}) {
+		t.Errorf("Expected b c, but got %v", g.Predecessors("d"))
+	}
+	if len(g.Neighbors("d")) != 0 {
+		t.Errorf("Expected no edges from d, but got %v", g.Neighbors("d"))
+	}
+
+	u := NewUndirected[int]()
+	u.AddWeightedEdge(1, 2, 5)
+	if edges := u.Edges(2); len(edges) != 1 || edges[0].To != 1 || edges[0].Weight != 5 {
+		t.Errorf("Expected an edge back from 2 to 1, but got %v", edges)
+	}
+	if u.String() != "undirected graph, 2 nodes, 1 edges" {
+		t.Errorf("Unexpected %q", u.String())
+	}
+}
+
+func TestNext(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddLabeledEdge("AAA", "BBB", "L")
+	g.AddLabeledEdge("AAA", "CCC", "R")
+	if next, ok := g.Next("AAA", "R"); !ok || next != "CCC" {
+		t.Errorf("Expected CCC, but got %s", next)
+	}
+	if _, ok := g.Next("BBB", "L"); ok {
+		t.Errorf("Expected no L edge from BBB")
+	}
+}
+
+func TestTraversal(t *testing.T) {
+	g := diamond()
+	g.AddEdge("d", "f")
+
+	var depths []int
+	g.BFS("a", func(node string, depth int) bool {
+		depths = append(depths, depth)
+		return true
+	})
+	if !reflect.DeepEqual(depths, []int{0, 1, 1, 2, 3}) {
+		t.Errorf("Expected depths 0 1 1 2 3, but got %v", depths)
+	}
+
+	var order []string
+	g.DFS("a", func(node string) bool {
+		order = append(order, node)
+		return node != "d"
+	})
+	if !reflect.DeepEqual(order, []string{"a", "b", "d"}) {
+		t.Errorf("Expected a b d, but got %v", order)
+	}
+
+	if reachable := g.Reachable("c"); !reflect.DeepEqual(reachable, []string{"c", "d", "f"}) {
+		t.Errorf("Expected c d f, but got %v", reachable)
+	}
+	if reachable := g.Reachable("x"); len(reachable) != 0 {
+		t.Errorf("Expected nothing reachable from an unknown node, but got %v", reachable)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := diamond()
+	order, err := g.TopologicalSort()
+	if err != nil || !reflect.DeepEqual(order, []string{"a", "e", "b", "c", "d"}) {
+		t.Errorf("Expected a e b c d, but got %v, %v", order, err)
+	}
+
+	g.AddEdge("d", "a")
+	if _, err := g.TopologicalSort(); err == nil {
+		t.Errorf("Expected an error for a cycle")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := diamond()
+	g.AddEdge("f", "e")
+	expected := [][]string{{"a", "b", "c", "d"}, {"e", "f"}}
+	if components := g.Components(); !reflect.DeepEqual(components, expected) {
+		t.Errorf("Expected %v, but got %v", expected, components)
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddWeightedEdge("s", "a", 1)
+	g.AddWeightedEdge("a", "e", 1)
+	g.AddWeightedEdge("s", "b", 4)
+	g.AddWeightedEdge("b", "a", 4)
+
+	length, path, ok := g.LongestPath("s", "e")
+	if !ok || length != 9 || !reflect.DeepEqual(path, []string{"s", "b", "a", "e"}) {
+		t.Errorf("Expected s b a e with 9, but got %v with %d", path, length)
+	}
+
+	g.AddNode("x")
+	if _, _, ok := g.LongestPath("s", "x"); ok {
+		t.Errorf("Expected no path to x")
+	}
+}
+
+// a grid of corridors: start, a loop through two junctions and the end
+//
+//	s . j . . e
+//	    .   .
+//	    . k .
+func TestCompress(t *testing.T) {
+	type cell struct{ r, c int }
+	g := NewUndirected[cell]()
+	path := []cell{{0, 0}, {0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}}
+	for idx := 1; idx < len(path); idx++ {
+		g.AddEdge(path[idx-1], path[idx])
+	}
+	loop := []cell{{0, 2}, {1, 2}, {2, 2}, {2, 3}, {2, 4}, {1, 4}, {0, 4}}
+	for idx := 1; idx < len(loop); idx++ {
+		g.AddEdge(loop[idx-1], loop[idx])
+	}
+
+	start, end := cell{0, 0}, cell{0, 5}
+	before, _, _ := g.LongestPath(start, end)
+	compressed := g.Compress(func(c cell) bool { return c == start || c == end })
+	if compressed.Len() != 4 {
+		t.Errorf("Expected start, end and two junctions, but got %v", compressed.Nodes())
+	}
+	after, _, _ := compressed.LongestPath(start, end)
+	if before != 9 || after != before {
+		t.Errorf("Expected 9 before and after compressing, but got %d and %d", before, after)
+	}
+}
+
+func TestCompressOneWay(t *testing.T) {
+	// b -> c can only be passed one way
+	g := NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "a")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "c")
+
+	compressed := g.Compress(func(node string) bool { return node == "a" || node == "d" })
+	if edges := compressed.Edges("a"); len(edges) != 1 || edges[0].To != "d" || edges[0].Weight != 3 {
+		t.Errorf("Expected a -> d with 3, but got %v", edges)
+	}
+	if edges := compressed.Edges("d"); len(edges) != 0 {
+		t.Errorf("Expected no way back from d, but got %v", edges)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddWeightedEdge("a", "b", 3)
+	g.AddLabeledEdge("b", "c", "L")
+	g.AddNode("d")
+
+	var out strings.Builder
+	if err := g.WriteDOT(&out, "test"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	expected := `graph "test" {
+	"a" -- "b" [label=3];
+	"b" -- "c" [label="L"];
+	"d";
+}
+`
+	if out.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, out.String())
+	}
+
+	d := NewDirected[int]()
+	d.AddEdge(1, 2)
+	out.Reset()
+	d.WriteDOT(&out, "d")
+	if !strings.Contains(out.String(), `"1" -> "2";`) {
+		t.Errorf("Expected a directed edge, but got %q", out.String())
+	}
+}
@@ -0,0 +1,266 @@
+package graph
+
+import (
+	"fmt"
+)
+
+// BFS visits the nodes reachable from start breadth first with their distance
+// in edges, it stops early once visit returns false
+func (g *Graph[K]) BFS(start K, visit func(node K, depth int) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	seen := map[K]bool{start: true}
+	level := []K{start}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []K
+		for _, node := range level {
+			if !visit(node, depth) {
+				return
+			}
+			for _, edge := range g.edges[node] {
+				if !seen[edge.To] {
+					seen[edge.To] = true
+					next = append(next, edge.To)
+				}
+			}
+		}
+		level = next
+	}
+}
+
+// DFS visits the nodes reachable from start depth first, each node before
+// the nodes below it. It stops early once visit returns false.
+func (g *Graph[K]) DFS(start K, visit func(node K) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	seen := make(map[K]bool)
+	stack := []K{start}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[node] {
+			continue
+		}
+		seen[node] = true
+		if !visit(node) {
+			return
+		}
+		// reversed so the first edge is followed first
+		edges := g.edges[node]
+		for idx := len(edges) - 1; idx >= 0; idx-- {
+			if !seen[edges[idx].To] {
+				stack = append(stack, edges[idx].To)
+			}
+		}
+	}
+}
+
+// Reachable returns start and all nodes that can be reached from it in BFS order
+func (g *Graph[K]) Reachable(start K) []K {
+	var result []K
+	g.BFS(start, func(node K, _ int) bool {
+		result = append(result, node)
+		return true
+	})
+	return result
+}
+
+// TopologicalSort orders the nodes so each edge leads to a later node, nodes
+// without order between them keep the order they were added. It fails for
+// graphs with a cycle and for undirected graphs.
+func (g *Graph[K]) TopologicalSort() ([]K, error) {
+	if !g.directed {
+		return nil, fmt.Errorf("topological sort of an undirected graph")
+	}
+	incoming := make(map[K]int)
+	for _, node := range g.nodes {
+		for _, edge := range g.edges[node] {
+			incoming[edge.To]++
+		}
+	}
+
+	var ready []K
+	for _, node := range g.nodes {
+		if incoming[node] == 0 {
+			ready = append(ready, node)
+		}
+	}
+	result := make([]K, 0, len(g.nodes))
+	for len(ready) > 0 {
+		node := ready[0]
+		ready = ready[1:]
+		result = append(result, node)
+		for _, edge := range g.edges[node] {
+			incoming[edge.To]--
+			if incoming[edge.To] == 0 {
+				ready = append(ready, edge.To)
+			}
+		}
+	}
+
+	if len(result) != len(g.nodes) {
+		return nil, fmt.Errorf("graph has a cycle")
+	}
+	return result, nil
+}
+
+// Components returns the connected components, edges of directed graphs
+// count in both directions. Components and their nodes are in the order the
+// nodes were added.
+func (g *Graph[K]) Components() [][]K {
+	links := make(map[K][]K)
+	for _, from := range g.nodes {
+		for _, edge := range g.edges[from] {
+			links[from] = append(links[from], edge.To)
+			links[edge.To] = append(links[edge.To], from)
+		}
+	}
+
+	component := make(map[K]int)
+	var result [][]K
+	for _, node := range g.nodes {
+		if _, ok := component[node]; ok {
+			continue
+		}
+		id := len(result)
+		component[node] = id
+		stack := []K{node}
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, next := range links[current] {
+				if _, ok := component[next]; !ok {
+					component[next] = id
+					stack = append(stack, next)
+				}
+			}
+		}
+		result = append(result, nil)
+	}
+
+	for _, node := range g.nodes {
+		id := component[node]
+		result[id] = append(result[id], node)
+	}
+	return result
+}
+
+// -------------------------- Longest path -----------------------------------
+
+// LongestPath returns the highest total weight of a path from start to end
+// that visits no node twice and the nodes of that path. This is NP hard, the
+// search tries all paths so it is only fast on small graphs, see Compress.
+func (g *Graph[K]) LongestPath(start K, end K) (int, []K, bool) {
+	if !g.HasNode(start) || !g.HasNode(end) {
+		return 0, nil, false
+	}
+
+	// nodes as indexes so visited is a slice
+	type arc struct {
+		to     int
+		weight int
+	}
+	arcs := make([][]arc, len(g.nodes))
+	for idx, node := range g.nodes {
+		for _, edge := range g.edges[node] {
+			arcs[idx] = append(arcs[idx], arc{to: g.index[edge.To], weight: edge.Weight})
+		}
+	}
+
+	target := g.index[end]
+	visited := make([]bool, len(g.nodes))
+	path := []int{g.index[start]}
+	var best []int
+	bestLength := 0
+
+	var search func(node int, length int)
+	search = func(node int, length int) {
+		if node == target {
+			if best == nil || length > bestLength {
+				bestLength = length
+				best = append(best[:0], path...)
+			}
+			return
+		}
+		visited[node] = true
+		for _, a := range arcs[node] {
+			if !visited[a.to] {
+				path = append(path, a.to)
+				search(a.to, length+a.weight)
+				path = path[:len(path)-1]
+			}
+		}
+		// backtracking
+		visited[node] = false
+	}
+	search(g.index[start], 0)
+
+	if best == nil {
+		return 0, nil, false
+	}
+	nodes := make([]K, len(best))
+	for idx, i := range best {
+		nodes[idx] = g.nodes[i]
+	}
+	return bestLength, nodes, true
+}
+
+// Compress returns a graph without the corridor nodes, these are nodes with
+// exactly two neighbours for which keep is false. Each chain of corridor
+// nodes becomes one edge with the sum of their weights, so paths between the
+// remaining nodes keep their length. Chains that can only be passed the other
+// way or lead nowhere are dropped.
+func (g *Graph[K]) Compress(keep func(K) bool) *Graph[K] {
+	neighbors := make(map[K]map[K]bool)
+	for _, from := range g.nodes {
+		for _, edge := range g.edges[from] {
+			if from == edge.To {
+				continue
+			}
+			for _, pair := range [][2]K{{from, edge.To}, {edge.To, from}} {
+				if neighbors[pair[0]] == nil {
+					neighbors[pair[0]] = make(map[K]bool)
+				}
+				neighbors[pair[0]][pair[1]] = true
+			}
+		}
+	}
+	isCorridor := func(node K) bool {
+		return !keep(node) && len(neighbors[node]) == 2
+	}
+
+	result := &Graph[K]{directed: g.directed, index: make(map[K]int), edges: make(map[K][]Edge[K])}
+	for _, node := range g.nodes {
+		if !isCorridor(node) {
+			result.AddNode(node)
+		}
+	}
+
+	for _, from := range result.nodes {
+		for _, edge := range g.edges[from] {
+			prev, current, weight := from, edge.To, edge.Weight
+			passable := true
+			for isCorridor(current) && current != from {
+				var next *Edge[K]
+				for idx, e := range g.edges[current] {
+					if e.To != prev {
+						next = &g.edges[current][idx]
+						break
+					}
+				}
+				if next == nil {
+					passable = false
+					break
+				}
+				prev, current, weight = current, next.To, weight+next.Weight
+			}
+			if passable && !isCorridor(current) {
+				// both ends of an undirected chain add their direction
+				result.addArc(from, Edge[K]{To: current, Weight: weight, Label: edge.Label})
+			}
+		}
+	}
+	return result
+}
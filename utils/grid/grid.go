@@ -0,0 +1,298 @@
+// Package grid is a generic 2D grid for the map shaped puzzle inputs. Cells
+// are addressed by Point{Row, Col} with row 0 at the top.
+package grid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// Point is a cell position, it doubles as direction or offset
+type Point struct {
+	Row int
+	Col int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{Row: p.Row + q.Row, Col: p.Col + q.Col}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{Row: p.Row - q.Row, Col: p.Col - q.Col}
+}
+
+// Scale multiplies a direction, eg to move n steps
+func (p Point) Scale(n int) Point {
+	return Point{Row: p.Row * n, Col: p.Col * n}
+}
+
+// Manhattan returns the taxicab distance of two points
+func (p Point) Manhattan(q Point) int {
+	return utils.Abs(p.Row-q.Row) + utils.Abs(p.Col-q.Col)
+}
+
+// TurnRight turns a direction by 90 degrees clockwise, eg North to East
+func (p Point) TurnRight() Point {
+	return Point{Row: p.Col, Col: -p.Row}
+}
+
+// TurnLeft turns a direction by 90 degrees counter clockwise, eg North to West
+func (p Point) TurnLeft() Point {
+	return Point{Row: -p.Col, Col: p.Row}
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.Row, p.Col)
+}
+
+var (
+	North = Point{Row: -1, Col: 0}
+	East  = Point{Row: 0, Col: 1}
+	South = Point{Row: 1, Col: 0}
+	West  = Point{Row: 0, Col: -1}
+)
+
+// Directions4 are the orthogonal directions clockwise from north
+var Directions4 = []Point{North, East, South, West}
+
+// Directions8 are all directions including diagonals clockwise from north
+var Directions8 = []Point{
+	North, North.Add(East), East, South.Add(East),
+	South, South.Add(West), West, North.Add(West),
+}
+
+// Grid is a rectangle of cells of type T stored row by row
+type Grid[T any] struct {
+	rows  int
+	cols  int
+	cells []T
+}
+
+// New returns a grid with all cells set to initial
+func New[T any](rows int, cols int, initial T) *Grid[T] {
+	g := &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+	for idx := range g.cells {
+		g.cells[idx] = initial
+	}
+	return g
+}
+
+// Parse builds a grid from equally long input lines, convert turns each
+// character into a cell. Errors are utils.ParseErrors with the position.
+func Parse[T any](input []string, convert func(char byte) (T, error)) (*Grid[T], error) {
+	if err := utils.CheckGrid(input); err != nil {
+		return nil, err
+	}
+	g := &Grid[T]{rows: len(input), cols: len(input[0]), cells: make([]T, 0, len(input)*len(input[0]))}
+	for row, line := range input {
+		for col := 0; col < len(line); col++ {
+			cell, err := convert(line[col])
+			if err != nil {
+				return nil, utils.NewLine(row, line).ErrorfAt(col, "%v", err)
+			}
+			g.cells = append(g.cells, cell)
+		}
+	}
+	return g, nil
+}
+
+// ParseBytes keeps the characters as they are
+func ParseBytes(input []string) (*Grid[byte], error) {
+	return Parse(input, func(char byte) (byte, error) { return char, nil })
+}
+
+// ParseOf accepts only the given characters
+func ParseOf(input []string, allowed string) (*Grid[byte], error) {
+	return Parse(input, func(char byte) (byte, error) {
+		if strings.IndexByte(allowed, char) < 0 {
+			return 0, fmt.Errorf("expected one of %q, got %q", allowed, char)
+		}
+		return char, nil
+	})
+}
+
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < g.rows && p.Col >= 0 && p.Col < g.cols
+}
+
+// At returns the cell at p and panics if p is outside like a slice does
+func (g *Grid[T]) At(p Point) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid.At() - %v outside of %dx%d grid", p, g.rows, g.cols))
+	}
+	return g.cells[p.Row*g.cols+p.Col]
+}
+
+// Get returns the cell at p, ok is false if p is outside
+func (g *Grid[T]) Get(p Point) (value T, ok bool) {
+	if !g.InBounds(p) {
+		return value, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+// Set changes the cell at p and panics if p is outside
+func (g *Grid[T]) Set(p Point, value T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid.Set() - %v outside of %dx%d grid", p, g.rows, g.cols))
+	}
+	g.cells[p.Row*g.cols+p.Col] = value
+}
+
+// Row returns the cells of a row, changing them changes the grid
+func (g *Grid[T]) Row(row int) []T {
+	return g.cells[row*g.cols : (row+1)*g.cols]
+}
+
+// -------------------------- Iteration --------------------------------------
+
+// Each calls fn for every cell row by row
+func (g *Grid[T]) Each(fn func(p Point, value T)) {
+	for idx, value := range g.cells {
+		fn(Point{Row: idx / g.cols, Col: idx % g.cols}, value)
+	}
+}
+
+func (g *Grid[T]) neighbors(p Point, directions []Point) []Point {
+	result := make([]Point, 0, len(directions))
+	for _, dir := range directions {
+		if next := p.Add(dir); g.InBounds(next) {
+			result = append(result, next)
+		}
+	}
+	return result
+}
+
+// Neighbors4 returns the orthogonal neighbours of p inside the grid
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return g.neighbors(p, Directions4)
+}
+
+// Neighbors8 returns the neighbours of p including diagonals inside the grid
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return g.neighbors(p, Directions8)
+}
+
+// Find returns the first cell row by row that matches
+func (g *Grid[T]) Find(match func(T) bool) (Point, bool) {
+	for idx, value := range g.cells {
+		if match(value) {
+			return Point{Row: idx / g.cols, Col: idx % g.cols}, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns all matching cells row by row
+func (g *Grid[T]) FindAll(match func(T) bool) []Point {
+	var result []Point
+	for idx, value := range g.cells {
+		if match(value) {
+			result = append(result, Point{Row: idx / g.cols, Col: idx % g.cols})
+		}
+	}
+	return result
+}
+
+func (g *Grid[T]) Count(match func(T) bool) int {
+	count := 0
+	for _, value := range g.cells {
+		if match(value) {
+			count++
+		}
+	}
+	return count
+}
+
+// -------------------------- Transformations --------------------------------
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{rows: g.rows, cols: g.cols, cells: cells}
+}
+
+// Fill sets all cells to value
+func (g *Grid[T]) Fill(value T) {
+	for idx := range g.cells {
+		g.cells[idx] = value
+	}
+}
+
+// builds a grid of the given size where cell p comes from source(p) of g
+func (g *Grid[T]) remap(rows int, cols int, source func(p Point) Point) *Grid[T] {
+	result := &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+	for idx := range result.cells {
+		result.cells[idx] = g.At(source(Point{Row: idx / cols, Col: idx % cols}))
+	}
+	return result
+}
+
+// Transpose mirrors the grid at its main diagonal, rows become columns
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.cols, g.rows, func(p Point) Point { return Point{Row: p.Col, Col: p.Row} })
+}
+
+// RotateClockwise turns the grid by 90 degrees, the left column becomes the top row
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	return g.remap(g.cols, g.rows, func(p Point) Point { return Point{Row: g.rows - 1 - p.Col, Col: p.Row} })
+}
+
+// RotateCounterClockwise turns the grid by 90 degrees, the top row becomes the left column
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	return g.remap(g.cols, g.rows, func(p Point) Point { return Point{Row: p.Col, Col: g.cols - 1 - p.Row} })
+}
+
+// -------------------------- Output -----------------------------------------
+
+// Lines renders each row as a line, cell turns a cell into its character
+func (g *Grid[T]) Lines(cell func(T) rune) []string {
+	lines := make([]string, g.rows)
+	var line strings.Builder
+	for row := 0; row < g.rows; row++ {
+		line.Reset()
+		for _, value := range g.Row(row) {
+			line.WriteRune(cell(value))
+		}
+		lines[row] = line.String()
+	}
+	return lines
+}
+
+// Render returns the grid as text with a newline after each row
+func (g *Grid[T]) Render(cell func(T) rune) string {
+	return strings.Join(g.Lines(cell), "\n") + "\n"
+}
+
+// String renders byte and rune grids as they were parsed, bool grids as # and .
+// and other cells with fmt if that is a single character
+func (g *Grid[T]) String() string {
+	return g.Render(func(value T) rune {
+		switch v := any(value).(type) {
+		case byte:
+			return rune(v)
+		case rune:
+			return v
+		case bool:
+			if v {
+				return '#'
+			}
+			return '.'
+		}
+		s := fmt.Sprint(value)
+		if len(s) == 1 {
+			return rune(s[0])
+		}
+		return '?'
+	})
+}
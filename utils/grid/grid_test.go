@@ -0,0 +1,170 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+var gridInput = []string{
+	"#.#",
+	"..S",
+}
+
+func TestParseAndRender(t *testing.T) {
+	g, err := ParseBytes(gridInput)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Errorf("Expected 2x3, but got %dx%d", g.Rows(), g.Cols())
+	}
+	if g.At(Point{Row: 1, Col: 2}) != 'S' {
+		t.Errorf("Expected S, but got %c", g.At(Point{Row: 1, Col: 2}))
+	}
+
+	expected := strings.Join(gridInput, "\n") + "\n"
+	if g.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, g.String())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := ParseOf(gridInput, ".#")
+	var parseErr *utils.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != 3 {
+		t.Errorf("Expected an error at line 2, column 3, but got %v", err)
+	}
+
+	_, err = ParseBytes([]string{"...", ".."})
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("Expected an error at line 2, but got %v", err)
+	}
+
+	_, err = ParseBytes(nil)
+	if err == nil {
+		t.Errorf("Expected an error for empty input")
+	}
+}
+
+func TestBounds(t *testing.T) {
+	g := New(2, 3, 0)
+	if _, ok := g.Get(Point{Row: 2, Col: 0}); ok {
+		t.Errorf("Expected row 2 to be outside")
+	}
+	if _, ok := g.Get(Point{Row: 0, Col: -1}); ok {
+		t.Errorf("Expected column -1 to be outside")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected Set outside the grid to panic")
+		}
+	}()
+	g.Set(Point{Row: 0, Col: 3}, 1)
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New(3, 3, 0)
+	tests := []struct {
+		p        Point
+		expected int
+		diagonal int
+	}{
+		{Point{Row: 0, Col: 0}, 2, 3},
+		{Point{Row: 0, Col: 1}, 3, 5},
+		{Point{Row: 1, Col: 1}, 4, 8},
+	}
+	for _, test := range tests {
+		if n := len(g.Neighbors4(test.p)); n != test.expected {
+			t.Errorf("Expected %d neighbours of %v, but got %d", test.expected, test.p, n)
+		}
+		if n := len(g.Neighbors8(test.p)); n != test.diagonal {
+			t.Errorf("Expected %d neighbours with diagonals of %v, but got %d", test.diagonal, test.p, n)
+		}
+	}
+}
+
+func TestRotateAndTranspose(t *testing.T) {
+	g, _ := ParseBytes([]string{
+		"abc",
+		"def",
+	})
+
+	tests := []struct {
+		name     string
+		result   *Grid[byte]
+		expected []string
+	}{
+		{"transpose", g.Transpose(), []string{"ad", "be", "cf"}},
+		{"clockwise", g.RotateClockwise(), []string{"da", "eb", "fc"}},
+		{"counter clockwise", g.RotateCounterClockwise(), []string{"cf", "be", "ad"}},
+	}
+	for _, test := range tests {
+		lines := test.result.Lines(func(c byte) rune { return rune(c) })
+		if !reflect.DeepEqual(lines, test.expected) {
+			t.Errorf("%s: Expected %v, but got %v", test.name, test.expected, lines)
+		}
+	}
+
+	back := g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise()
+	if back.String() != g.String() {
+		t.Errorf("Expected four rotations to give the grid, but got %q", back.String())
+	}
+}
+
+func TestFindCountClone(t *testing.T) {
+	g, _ := ParseBytes(gridInput)
+	isWall := func(c byte) bool { return c == '#' }
+
+	if p, ok := g.Find(isWall); !ok || p != (Point{Row: 0, Col: 0}) {
+		t.Errorf("Expected first wall at (0,0), but got %v", p)
+	}
+	if walls := g.FindAll(isWall); len(walls) != 2 || walls[1] != (Point{Row: 0, Col: 2}) {
+		t.Errorf("Expected walls at (0,0) and (0,2), but got %v", walls)
+	}
+
+	clone := g.Clone()
+	clone.Fill('#')
+	if g.Count(isWall) != 2 || clone.Count(isWall) != 6 {
+		t.Errorf("Expected 2 and 6 walls, but got %d and %d", g.Count(isWall), clone.Count(isWall))
+	}
+}
+
+func TestRenderOtherTypes(t *testing.T) {
+	g := New(1, 3, false)
+	g.Set(Point{Row: 0, Col: 1}, true)
+	if g.String() != ".#.\n" {
+		t.Errorf("Expected %q, but got %q", ".#.\n", g.String())
+	}
+
+	numbers := New(1, 2, 7)
+	rendered := numbers.Render(func(i int) rune { return rune(fmt.Sprint(i)[0]) })
+	if rendered != "77\n" {
+		t.Errorf("Expected %q, but got %q", "77\n", rendered)
+	}
+}
+
+func TestPoint(t *testing.T) {
+	p := Point{Row: 2, Col: 3}
+	if p.Add(South.Scale(2)) != (Point{Row: 4, Col: 3}) {
+		t.Errorf("Expected (4,3), but got %v", p.Add(South.Scale(2)))
+	}
+	if d := p.Manhattan(Point{Row: -1, Col: 5}); d != 5 {
+		t.Errorf("Expected 5, but got %d", d)
+	}
+}
+
+func TestTurn(t *testing.T) {
+	for idx, dir := range Directions4 {
+		right := Directions4[(idx+1)%4]
+		left := Directions4[(idx+3)%4]
+		if dir.TurnRight() != right || dir.TurnLeft() != left {
+			t.Errorf("%v: Expected right %v and left %v, but got %v and %v", dir, right, left, dir.TurnRight(), dir.TurnLeft())
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import "container/heap"
+
+// PriorityQueue is a binary heap of items, Pop returns the item that is less
+// than all others by the less function given to NewPriorityQueue
+type PriorityQueue[T any] struct {
+	items heapItems[T]
+}
+
+// adapts the items to heap.Interface
+type heapItems[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (h heapItems[T]) Len() int           { return len(h.values) }
+func (h heapItems[T]) Less(i, j int) bool { return h.less(h.values[i], h.values[j]) }
+func (h heapItems[T]) Swap(i, j int)      { h.values[i], h.values[j] = h.values[j], h.values[i] }
+
+func (h *heapItems[T]) Push(x any) {
+	h.values = append(h.values, x.(T))
+}
+
+func (h *heapItems[T]) Pop() any {
+	n := len(h.values)
+	item := h.values[n-1]
+	var zero T
+	h.values[n-1] = zero
+	h.values = h.values[:n-1]
+	return item
+}
+
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{items: heapItems[T]{less: less}}
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return q.items.Len()
+}
+
+func (q *PriorityQueue[T]) Push(item T) {
+	heap.Push(&q.items, item)
+}
+
+// Pop removes and returns the least item, ok is false if the queue is empty
+func (q *PriorityQueue[T]) Pop() (item T, ok bool) {
+	if q.items.Len() == 0 {
+		return item, false
+	}
+	return heap.Pop(&q.items).(T), true
+}
+
+// Peek returns the least item without removing it
+func (q *PriorityQueue[T]) Peek() (item T, ok bool) {
+	if q.items.Len() == 0 {
+		return item, false
+	}
+	return q.items.values[0], true
+}
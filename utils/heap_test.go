@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPriorityQueueOrder(t *testing.T) {
+	queue := NewPriorityQueue(func(a, b int) bool { return a < b })
+	values := rand.New(rand.NewSource(23)).Perm(100)
+	for _, value := range values {
+		queue.Push(value)
+	}
+	if queue.Len() != len(values) {
+		t.Fatalf("Expected %d items, but got %d", len(values), queue.Len())
+	}
+
+	sort.Ints(values)
+	for _, expected := range values {
+		if peek, _ := queue.Peek(); peek != expected {
+			t.Errorf("Expected peek %d, but got %d", expected, peek)
+		}
+		if result, ok := queue.Pop(); !ok || result != expected {
+			t.Errorf("Expected %d, but got %d", expected, result)
+		}
+	}
+
+	if _, ok := queue.Pop(); ok {
+		t.Errorf("Expected an empty queue")
+	}
+}
+
+func TestPriorityQueueInterleaved(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	queue := NewPriorityQueue(func(a, b task) bool { return a.priority > b.priority })
+	queue.Push(task{"low", 1})
+	queue.Push(task{"high", 9})
+	if result, _ := queue.Pop(); result.name != "high" {
+		t.Errorf("Expected high, but got %s", result.name)
+	}
+	queue.Push(task{"mid", 5})
+	for _, expected := range []string{"mid", "low"} {
+		if result, _ := queue.Pop(); result.name != expected {
+			t.Errorf("Expected %s, but got %s", expected, result.name)
+		}
+	}
+}
@@ -2,26 +2,38 @@ package utils
 
 import (
 	"bufio"
-	"log"
+	"fmt"
+	"io"
 	"os"
 )
 
-func ReadDataFile(filename string) []string {
+// longest line ReadDataFile accepts, day 15 is a single line of ~23k chars
+// which is fine with the default of 64k but generated inputs may not be
+const MAX_LINE_LENGTH int = 16 * 1024 * 1024
+
+func ReadDataFile(filename string) ([]string, error) {
 	file, err := os.Open(filename)
 	if err != nil {
-		// log.Fates does exit the prog
-		log.Fatal(err)
+		return nil, err
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	
+	return ReadLines(file, filename)
+}
+
+// ReadLines splits the input into lines, name is only used in errors
+func ReadLines(reader io.Reader, name string) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), MAX_LINE_LENGTH)
+
 	var lines []string
 	for scanner.Scan() {
 		line := scanner.Text()
 		lines = append(lines, line)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s line %d: %w", name, len(lines)+1, err)
+	}
 
-	return lines
+	return lines, nil
 }
-
@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Interval is the closed range of integers from Start to End, both included.
+// An interval with End < Start is empty, all empty intervals are equal in
+// meaning but not for ==, use Empty to test for them.
+type Interval struct {
+	Start int
+	End   int
+}
+
+func NewInterval(start int, end int) Interval {
+	return Interval{Start: start, End: end}
+}
+
+// IntervalOf returns the interval of length values starting at start, eg a
+// range given as start and length in the puzzle input. It is not ok for a
+// negative length or if the last value does not fit into an int.
+func IntervalOf(start int, length int) (Interval, bool) {
+	switch {
+	case length < 0:
+		return Interval{}, false
+	case length == 0:
+		return Interval{Start: 1, End: 0}, true
+	case start > math.MaxInt-(length-1):
+		return Interval{}, false
+	}
+	return Interval{Start: start, End: start + length - 1}, true
+}
+
+func (i Interval) Empty() bool {
+	return i.End < i.Start
+}
+
+// Len returns the number of values in the interval, it is not ok if the
+// number does not fit into an int, eg for [0..math.MaxInt]
+func (i Interval) Len() (int, bool) {
+	if i.Empty() {
+		return 0, true
+	}
+	// End - Start + 1 > math.MaxInt, compared without computing it
+	if i.Start <= 0 && i.End >= math.MaxInt+i.Start {
+		return 0, false
+	}
+	return i.End - i.Start + 1, true
+}
+
+func (i Interval) Contains(value int) bool {
+	return i.Start <= value && value <= i.End
+}
+
+// Overlaps is true if both intervals have a value in common
+func (i Interval) Overlaps(other Interval) bool {
+	return !i.Intersect(other).Empty()
+}
+
+// Intersect returns the values in both intervals, it may be empty
+func (i Interval) Intersect(other Interval) Interval {
+	return Interval{Start: Max(i.Start, other.Start), End: Min(i.End, other.End)}
+}
+
+// Difference returns the values of i not in other, these are up to two
+// intervals below and above other. Empty intervals are not returned.
+func (i Interval) Difference(other Interval) []Interval {
+	if i.Empty() {
+		return nil
+	}
+	if !i.Overlaps(other) {
+		return []Interval{i}
+	}
+	// compared before stepping past other, so math.MinInt and math.MaxInt
+	// ends do not wrap
+	var result []Interval
+	if i.Start < other.Start {
+		result = append(result, Interval{Start: i.Start, End: other.Start - 1})
+	}
+	if other.End < i.End {
+		result = append(result, Interval{Start: other.End + 1, End: i.End})
+	}
+	return result
+}
+
+// Split returns the part of i inside other and the parts outside of it
+func (i Interval) Split(other Interval) (Interval, []Interval) {
+	return i.Intersect(other), i.Difference(other)
+}
+
+// SplitAt splits i into the values below value and the values from value on,
+// either part may be empty
+func (i Interval) SplitAt(value int) (Interval, Interval) {
+	if value == math.MinInt {
+		return Interval{Start: 1, End: 0}, i
+	}
+	return Interval{Start: i.Start, End: Min(i.End, value-1)}, Interval{Start: Max(i.Start, value), End: i.End}
+}
+
+// Shift moves the interval by delta, it is not ok if the moved values do
+// not fit into an int. Empty intervals stay as they are.
+func (i Interval) Shift(delta int) (Interval, bool) {
+	if i.Empty() {
+		return i, true
+	}
+	if (delta > 0 && i.End > math.MaxInt-delta) || (delta < 0 && i.Start < math.MinInt-delta) {
+		return Interval{}, false
+	}
+	return Interval{Start: i.Start + delta, End: i.End + delta}, true
+}
+
+func (i Interval) String() string {
+	if i.Empty() {
+		return "[]"
+	}
+	return fmt.Sprintf("[%d..%d]", i.Start, i.End)
+}
+
+// apart is true if a ends before b starts and at least one value lies between
+// them, a.End+1 cannot wrap as a.End is below b.Start
+func apart(a Interval, b Interval) bool {
+	return a.End < b.Start && a.End+1 < b.Start
+}
+
+// -------------------------- Interval sets ----------------------------------
+
+// IntervalSet is a set of integers stored as sorted intervals that neither
+// overlap nor touch. The zero value is an empty set.
+type IntervalSet struct {
+	intervals []Interval
+}
+
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	var set IntervalSet
+	for _, i := range intervals {
+		set = set.Add(i)
+	}
+	return set
+}
+
+// Add returns the set with all values of i added
+func (s IntervalSet) Add(i Interval) IntervalSet {
+	if i.Empty() {
+		return s
+	}
+	result := IntervalSet{intervals: make([]Interval, 0, len(s.intervals)+1)}
+	for _, current := range s.intervals {
+		switch {
+		case apart(current, i):
+			result.intervals = append(result.intervals, current)
+		case apart(i, current):
+			if !i.Empty() {
+				result.intervals = append(result.intervals, i)
+				i = Interval{Start: 1, End: 0}
+			}
+			result.intervals = append(result.intervals, current)
+		default:
+			// overlapping or touching, merge into i
+			i = Interval{Start: Min(i.Start, current.Start), End: Max(i.End, current.End)}
+		}
+	}
+	if !i.Empty() {
+		result.intervals = append(result.intervals, i)
+	}
+	return result
+}
+
+// Union returns the values in either set
+func (s IntervalSet) Union(other IntervalSet) IntervalSet {
+	result := s
+	for _, i := range other.intervals {
+		result = result.Add(i)
+	}
+	return result
+}
+
+// Intersect returns the values in both sets
+func (s IntervalSet) Intersect(other IntervalSet) IntervalSet {
+	var result IntervalSet
+	for _, a := range s.intervals {
+		for _, b := range other.intervals {
+			if common := a.Intersect(b); !common.Empty() {
+				result.intervals = append(result.intervals, common)
+			}
+		}
+	}
+	// the pieces are sorted and apart as they come from sorted disjoint intervals
+	return result
+}
+
+// Difference returns the values of s not in other
+func (s IntervalSet) Difference(other IntervalSet) IntervalSet {
+	var result IntervalSet
+	for _, a := range s.intervals {
+		pieces := []Interval{a}
+		for _, b := range other.intervals {
+			var next []Interval
+			for _, piece := range pieces {
+				next = append(next, piece.Difference(b)...)
+			}
+			pieces = next
+		}
+		result.intervals = append(result.intervals, pieces...)
+	}
+	return result
+}
+
+func (s IntervalSet) Contains(value int) bool {
+	idx := sort.Search(len(s.intervals), func(idx int) bool { return s.intervals[idx].End >= value })
+	return idx < len(s.intervals) && s.intervals[idx].Contains(value)
+}
+
+func (s IntervalSet) Empty() bool {
+	return len(s.intervals) == 0
+}
+
+// Len returns the number of values in the set, it is not ok if the number
+// does not fit into an int
+func (s IntervalSet) Len() (int, bool) {
+	total := 0
+	for _, i := range s.intervals {
+		n, ok := i.Len()
+		if !ok || n > math.MaxInt-total {
+			return 0, false
+		}
+		total += n
+	}
+	return total, true
+}
+
+// Min returns the lowest value, the set must not be empty
+func (s IntervalSet) Min() int {
+	return s.intervals[0].Start
+}
+
+// Intervals returns the sorted intervals of the set
+func (s IntervalSet) Intervals() []Interval {
+	return s.intervals
+}
+
+func (s IntervalSet) String() string {
+	parts := make([]string, len(s.intervals))
+	for idx, i := range s.intervals {
+		parts[idx] = i.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
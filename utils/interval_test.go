@@ -0,0 +1,266 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// the properties are checked against plain sets of values in a small domain
+const INTERVAL_DOMAIN = 12
+
+func valuesOf(contains func(int) bool) map[int]bool {
+	values := make(map[int]bool)
+	for v := -2; v <= INTERVAL_DOMAIN+8; v++ {
+		if contains(v) {
+			values[v] = true
+		}
+	}
+	return values
+}
+
+func randomInterval(r *rand.Rand) Interval {
+	// about one in seven is empty
+	start := r.Intn(INTERVAL_DOMAIN)
+	return Interval{Start: start, End: start + r.Intn(7) - 1}
+}
+
+func randomIntervalSet(r *rand.Rand) IntervalSet {
+	var set IntervalSet
+	for n := r.Intn(4); n > 0; n-- {
+		set = set.Add(randomInterval(r))
+	}
+	return set
+}
+
+// length is the Len of an interval or set that has to fit into an int
+func length(t *testing.T, i interface{ Len() (int, bool) }) int {
+	t.Helper()
+	n, ok := i.Len()
+	if !ok {
+		t.Fatalf("%v: Expected a length that fits into an int", i)
+	}
+	return n
+}
+
+func intervalsContain(intervals []Interval) func(int) bool {
+	return func(v int) bool {
+		for _, i := range intervals {
+			if i.Contains(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestIntervalBasics(t *testing.T) {
+	i, ok := IntervalOf(79, 14)
+	if !ok || i != (Interval{Start: 79, End: 92}) {
+		t.Errorf("Expected [79..92], but got %v", i)
+	}
+	if length(t, i) != 14 {
+		t.Errorf("Expected 14, but got %d", length(t, i))
+	}
+	if !i.Contains(92) || i.Contains(93) || i.Contains(78) {
+		t.Errorf("Expected %v to contain exactly 79 to 92", i)
+	}
+	if empty := NewInterval(3, 2); !empty.Empty() || length(t, empty) != 0 || empty.String() != "[]" {
+		t.Errorf("Expected [3..2] to be empty, but got %v with length %d", empty, length(t, empty))
+	}
+	if !NewInterval(1, 5).Overlaps(NewInterval(5, 9)) || NewInterval(1, 4).Overlaps(NewInterval(5, 9)) {
+		t.Errorf("Expected closed intervals to overlap at a common end only")
+	}
+}
+
+func TestIntervalProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	for n := 0; n < 2000; n++ {
+		a, b := randomInterval(r), randomInterval(r)
+		inA, inB := valuesOf(a.Contains), valuesOf(b.Contains)
+
+		if len(inA) != length(t, a) {
+			t.Fatalf("%v: Expected length %d, but got %d", a, len(inA), length(t, a))
+		}
+
+		expected := valuesOf(func(v int) bool { return inA[v] && inB[v] })
+		if result := valuesOf(a.Intersect(b).Contains); !reflect.DeepEqual(result, expected) {
+			t.Fatalf("%v intersect %v: Expected %v, but got %v", a, b, expected, result)
+		}
+		if a.Overlaps(b) != (len(expected) > 0) {
+			t.Fatalf("%v overlaps %v: Expected %t", a, b, len(expected) > 0)
+		}
+
+		expected = valuesOf(func(v int) bool { return inA[v] && !inB[v] })
+		difference := a.Difference(b)
+		if result := valuesOf(intervalsContain(difference)); !reflect.DeepEqual(result, expected) {
+			t.Fatalf("%v minus %v: Expected %v, but got %v", a, b, expected, result)
+		}
+		for _, piece := range difference {
+			if piece.Empty() {
+				t.Fatalf("%v minus %v: Expected no empty pieces, but got %v", a, b, difference)
+			}
+		}
+
+		// split gives every value of a exactly once
+		inside, outside := a.Split(b)
+		total := length(t, inside)
+		if inside.Empty() {
+			total = 0
+		}
+		for _, piece := range outside {
+			total += length(t, piece)
+		}
+		if total != length(t, a) {
+			t.Fatalf("%v split by %v: Expected %d values, but got %d", a, b, length(t, a), total)
+		}
+
+		at := r.Intn(INTERVAL_DOMAIN)
+		below, above := a.SplitAt(at)
+		if length(t, below)+length(t, above) != length(t, a) || (!below.Empty() && below.End >= at) || (!above.Empty() && above.Start < at) {
+			t.Fatalf("%v split at %d: Expected a partition, but got %v and %v", a, at, below, above)
+		}
+	}
+}
+
+func TestIntervalSetProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for n := 0; n < 2000; n++ {
+		a, b := randomIntervalSet(r), randomIntervalSet(r)
+		inA, inB := valuesOf(a.Contains), valuesOf(b.Contains)
+
+		// the intervals are sorted, not empty and neither overlap nor touch
+		for _, set := range []IntervalSet{a, b, a.Union(b), a.Intersect(b), a.Difference(b)} {
+			for idx, i := range set.Intervals() {
+				if i.Empty() || (idx > 0 && set.Intervals()[idx-1].End+1 >= i.Start) {
+					t.Fatalf("Expected disjoint sorted intervals, but got %v", set)
+				}
+			}
+			if len(valuesOf(set.Contains)) != length(t, set) {
+				t.Fatalf("%v: Expected length %d, but got %d", set, len(valuesOf(set.Contains)), length(t, set))
+			}
+		}
+
+		tests := []struct {
+			name     string
+			result   IntervalSet
+			expected func(int) bool
+		}{
+			{"union", a.Union(b), func(v int) bool { return inA[v] || inB[v] }},
+			{"intersect", a.Intersect(b), func(v int) bool { return inA[v] && inB[v] }},
+			{"difference", a.Difference(b), func(v int) bool { return inA[v] && !inB[v] }},
+		}
+		for _, test := range tests {
+			if result, expected := valuesOf(test.result.Contains), valuesOf(test.expected); !reflect.DeepEqual(result, expected) {
+				t.Fatalf("%v %s %v: Expected %v, but got %v", a, test.name, b, expected, result)
+			}
+		}
+	}
+}
+
+func TestIntervalSetMerge(t *testing.T) {
+	set := NewIntervalSet(NewInterval(5, 7), NewInterval(1, 2), NewInterval(3, 4), NewInterval(10, 9))
+	if set.String() != "{[1..7]}" {
+		t.Errorf("Expected {[1..7]}, but got %v", set)
+	}
+	if set.Min() != 1 || length(t, set) != 7 {
+		t.Errorf("Expected min 1 and length 7, but got %d and %d", set.Min(), length(t, set))
+	}
+}
+
+// stepping past math.MinInt or math.MaxInt must not wrap around
+func TestIntervalFullRange(t *testing.T) {
+	full := NewInterval(math.MinInt, math.MaxInt)
+
+	if difference := full.Difference(NewInterval(0, 9)); !reflect.DeepEqual(difference, []Interval{{math.MinInt, -1}, {10, math.MaxInt}}) {
+		t.Errorf("Expected [MinInt..-1] and [10..MaxInt], but got %v", difference)
+	}
+	if difference := full.Difference(full); len(difference) != 0 {
+		t.Errorf("Expected nothing left, but got %v", difference)
+	}
+	if difference := NewInterval(math.MinInt, 5).Difference(NewInterval(math.MinInt, 0)); !reflect.DeepEqual(difference, []Interval{{1, 5}}) {
+		t.Errorf("Expected [1..5], but got %v", difference)
+	}
+	if below, above := full.SplitAt(math.MinInt); !below.Empty() || above != full {
+		t.Errorf("Expected nothing below MinInt, but got %v and %v", below, above)
+	}
+
+	set := NewIntervalSet(NewInterval(math.MinInt, -1), NewInterval(5, math.MaxInt))
+	if set.String() != fmt.Sprintf("{[%d..-1] [5..%d]}", math.MinInt, math.MaxInt) {
+		t.Errorf("Expected the gap from 0 to 4 to stay, but got %v", set)
+	}
+	if set = set.Add(NewInterval(0, 4)); !reflect.DeepEqual(set.Intervals(), []Interval{full}) {
+		t.Errorf("Expected the full range, but got %v", set)
+	}
+	if set = NewIntervalSet(full, NewInterval(math.MinInt, math.MinInt), NewInterval(math.MaxInt, math.MaxInt)); !reflect.DeepEqual(set.Intervals(), []Interval{full}) {
+		t.Errorf("Expected the full range, but got %v", set)
+	}
+	if set = NewIntervalSet(full).Difference(NewIntervalSet(NewInterval(math.MinInt, 0))); !reflect.DeepEqual(set.Intervals(), []Interval{{1, math.MaxInt}}) {
+		t.Errorf("Expected [1..MaxInt], but got %v", set)
+	}
+
+	lengths := []struct {
+		interval Interval
+		expected int
+		ok       bool
+	}{
+		{full, 0, false},
+		{NewInterval(0, math.MaxInt), 0, false},
+		{NewInterval(1, math.MaxInt), math.MaxInt, true},
+		{NewInterval(math.MinInt, -2), math.MaxInt, true},
+		{NewInterval(math.MinInt, -1), 0, false},
+		{NewInterval(math.MaxInt, math.MaxInt), 1, true},
+		{NewInterval(math.MaxInt, math.MinInt), 0, true},
+	}
+	for _, test := range lengths {
+		if n, ok := test.interval.Len(); n != test.expected || ok != test.ok {
+			t.Errorf("%v: Expected length %d ok %t, but got %d ok %t", test.interval, test.expected, test.ok, n, ok)
+		}
+	}
+	if n, ok := NewIntervalSet(full).Len(); ok {
+		t.Errorf("Expected the length of the full range not to fit, but got %d", n)
+	}
+	if n, ok := NewIntervalSet(NewInterval(math.MinInt, -2), NewInterval(0, 0)).Len(); ok {
+		t.Errorf("Expected the sum of the lengths not to fit, but got %d", n)
+	}
+
+	intervals := []struct {
+		start, length int
+		expected      Interval
+		ok            bool
+	}{
+		{math.MaxInt, 1, NewInterval(math.MaxInt, math.MaxInt), true},
+		{math.MaxInt, 2, Interval{}, false},
+		{1, math.MaxInt, NewInterval(1, math.MaxInt), true},
+		{2, math.MaxInt, Interval{}, false},
+		{math.MinInt, math.MaxInt, NewInterval(math.MinInt, -2), true},
+		{math.MinInt, 0, Interval{Start: 1, End: 0}, true},
+		{0, -1, Interval{}, false},
+	}
+	for _, test := range intervals {
+		if i, ok := IntervalOf(test.start, test.length); i != test.expected || ok != test.ok {
+			t.Errorf("%d values from %d: Expected %v ok %t, but got %v ok %t", test.length, test.start, test.expected, test.ok, i, ok)
+		}
+	}
+
+	shifts := []struct {
+		interval Interval
+		delta    int
+		expected Interval
+		ok       bool
+	}{
+		{NewInterval(0, 9), math.MaxInt - 9, NewInterval(math.MaxInt-9, math.MaxInt), true},
+		{NewInterval(0, 9), math.MaxInt - 8, Interval{}, false},
+		{NewInterval(-9, 0), math.MinInt + 9, NewInterval(math.MinInt, math.MinInt+9), true},
+		{NewInterval(-9, 0), math.MinInt + 8, Interval{}, false},
+		{full, 1, Interval{}, false},
+		{full, 0, full, true},
+	}
+	for _, test := range shifts {
+		if i, ok := test.interval.Shift(test.delta); i != test.expected || ok != test.ok {
+			t.Errorf("%v shifted by %d: Expected %v ok %t, but got %v ok %t", test.interval, test.delta, test.expected, test.ok, i, ok)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"strconv"
+	"unsafe"
+)
+
+// Integer is the constraint of the integer extraction helpers
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// Ints returns every integer of the line in order, anything else separates
+// them. A '-' right before the digits makes a number negative unless it
+// follows a digit like in "10-20". Numbers that do not fit into T and
+// negative numbers for unsigned types are errors with their column.
+func Ints[T Integer](line Line) ([]T, error) {
+	var zero T
+	bits := int(unsafe.Sizeof(zero)) * 8
+	signed := zero-1 < zero
+
+	var result []T
+	text := line.Text
+	for idx := 0; idx < len(text); idx++ {
+		if !isDigit(text[idx]) {
+			continue
+		}
+		start := idx
+		if start > 0 && text[start-1] == '-' && (start == 1 || !isDigit(text[start-2])) {
+			start--
+		}
+		end := idx
+		for end < len(text) && isDigit(text[end]) {
+			end++
+		}
+		idx = end
+
+		number := text[start:end]
+		if signed {
+			value, err := strconv.ParseInt(number, 10, bits)
+			if err != nil {
+				return nil, line.ErrorfAt(start, "%v", numError(err))
+			}
+			result = append(result, T(value))
+		} else {
+			if number[0] == '-' {
+				return nil, line.ErrorfAt(start, "negative number %s", number)
+			}
+			value, err := strconv.ParseUint(number, 10, bits)
+			if err != nil {
+				return nil, line.ErrorfAt(start, "%v", numError(err))
+			}
+			result = append(result, T(value))
+		}
+	}
+	return result, nil
+}
+
+// AllInts returns the integers of every line of the input, see Ints
+func AllInts[T Integer](input []string) ([][]T, error) {
+	result := make([][]T, len(input))
+	for idx, text := range input {
+		ints, err := Ints[T](NewLine(idx, text))
+		if err != nil {
+			return nil, err
+		}
+		result[idx] = ints
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []int
+	}{
+		{"0 3 6 9 12 15", []int{0, 3, 6, 9, 12, 15}},
+		{"-1 -2  -3", []int{-1, -2, -3}},
+		{"19, 13, 30 @ -2,  1, -2", []int{19, 13, 30, -2, 1, -2}},
+		{"1,0,1~1,2,1", []int{1, 0, 1, 1, 2, 1}},
+		{"Card 1: 41 48 | 83 86", []int{1, 41, 48, 83, 86}},
+		{"10-20", []int{10, 20}},
+		{"no numbers", nil},
+	}
+
+	for _, test := range tests {
+		result, err := Ints[int](NewLine(0, test.line))
+		if err != nil {
+			t.Errorf("%q: %v", test.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("%q: expected %v, but got %v", test.line, test.expected, result)
+		}
+	}
+}
+
+func TestIntsWidths(t *testing.T) {
+	line := NewLine(0, "seeds: 3169137700 271717609 18446744073709551615")
+	values, err := Ints[uint64](line)
+	if err != nil || values[2] != 18446744073709551615 {
+		t.Errorf("Expected max uint64, but got %v %v", values, err)
+	}
+
+	// out of range for int64 at the last number, for int32 at the first one
+	var parseErr *ParseError
+	if _, err := Ints[int64](line); !errors.As(err, &parseErr) || parseErr.Column != 29 {
+		t.Errorf("Expected int64 overflow at column 29, but got %v", err)
+	}
+	if _, err := Ints[int32](line); !errors.As(err, &parseErr) || parseErr.Column != 8 {
+		t.Errorf("Expected int32 overflow at column 8, but got %v", err)
+	}
+}
+
+func TestIntsNegativeUnsigned(t *testing.T) {
+	_, err := Ints[uint64](NewLine(2, "5 -3"))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 || parseErr.Column != 3 {
+		t.Errorf("Expected error at line 3, column 3, but got %v", err)
+	}
+}
+
+func TestAllInts(t *testing.T) {
+	result, err := AllInts[int64]([]string{"1 2", "", "-3"})
+	expected := [][]int64{{1, 2}, nil, {-3}}
+	if err != nil || !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v %v", expected, result, err)
+	}
+}
+
+func FuzzInts(f *testing.F) {
+	f.Add("19, 13, 30 @ -2,  1, -2")
+	f.Add("seeds: 3169137700 271717609 18446744073709551615")
+	f.Add("10-20 --5")
+	f.Fuzz(func(t *testing.T, text string) {
+		_, err := Ints[int32](NewLine(0, text))
+		var parseErr *ParseError
+		if err != nil && (!errors.As(err, &parseErr) || parseErr.Column < 1 || parseErr.Column > len(text)+1) {
+			t.Errorf("%q: expected a ParseError inside the line, but got %v", text, err)
+		}
+	})
+}
@@ -0,0 +1,238 @@
+// Package linalg is exact linear algebra over rational numbers: matrices,
+// Gaussian elimination, determinant, rank and solving linear systems.
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+	"github.com/cdr74/AdventOfCode2023/utils/number"
+)
+
+// ErrNoSolution is returned for systems whose equations contradict each other
+var ErrNoSolution = errors.New("linalg: no solution")
+
+// ErrUnderdetermined is returned for systems with infinitely many solutions
+var ErrUnderdetermined = errors.New("linalg: infinitely many solutions")
+
+// Matrix is a matrix of exact rational numbers
+type Matrix struct {
+	rows  int
+	cols  int
+	cells []number.Rat
+}
+
+// New returns a rows x cols matrix of zeros
+func New(rows, cols int) *Matrix {
+	return &Matrix{rows: rows, cols: cols, cells: make([]number.Rat, rows*cols)}
+}
+
+// Identity returns the size x size identity matrix
+func Identity(size int) *Matrix {
+	m := New(size, size)
+	for idx := 0; idx < size; idx++ {
+		m.Set(idx, idx, number.RatOf(1))
+	}
+	return m
+}
+
+// Of returns the matrix of the rows of integers, all rows need the same length
+func Of[T utils.Integer](values [][]T) *Matrix {
+	rats := make([][]number.Rat, len(values))
+	for row, line := range values {
+		rats[row] = make([]number.Rat, len(line))
+		for col, value := range line {
+			rats[row][col] = number.RatOf(value)
+		}
+	}
+	return OfRats(rats)
+}
+
+// OfRats returns the matrix of the rows, all rows need the same length
+func OfRats(values [][]number.Rat) *Matrix {
+	cols := 0
+	if len(values) > 0 {
+		cols = len(values[0])
+	}
+	m := New(len(values), cols)
+	for row, line := range values {
+		if len(line) != cols {
+			panic(fmt.Sprintf("linalg.OfRats() - row %d has %d values, expected %d", row, len(line), cols))
+		}
+		copy(m.cells[row*cols:], line)
+	}
+	return m
+}
+
+func (m *Matrix) Rows() int {
+	return m.rows
+}
+
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+func (m *Matrix) At(row, col int) number.Rat {
+	return m.cells[row*m.cols+col]
+}
+
+func (m *Matrix) Set(row, col int, value number.Rat) {
+	m.cells[row*m.cols+col] = value
+}
+
+func (m *Matrix) Clone() *Matrix {
+	clone := New(m.rows, m.cols)
+	copy(clone.cells, m.cells)
+	return clone
+}
+
+// Mul returns the product m * o, the columns of m have to match the rows of o
+func (m *Matrix) Mul(o *Matrix) *Matrix {
+	if m.cols != o.rows {
+		panic(fmt.Sprintf("linalg.Mul() - %dx%d times %dx%d", m.rows, m.cols, o.rows, o.cols))
+	}
+	result := New(m.rows, o.cols)
+	for row := 0; row < m.rows; row++ {
+		for col := 0; col < o.cols; col++ {
+			var sum number.Rat
+			for k := 0; k < m.cols; k++ {
+				sum = sum.Add(m.At(row, k).Mul(o.At(k, col)))
+			}
+			result.Set(row, col, sum)
+		}
+	}
+	return result
+}
+
+// MulVector returns m * v
+func (m *Matrix) MulVector(v []number.Rat) []number.Rat {
+	column := New(len(v), 1)
+	copy(column.cells, v)
+	return m.Mul(column).cells
+}
+
+func (m *Matrix) String() string {
+	var sb strings.Builder
+	for row := 0; row < m.rows; row++ {
+		for col := 0; col < m.cols; col++ {
+			if col > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(m.At(row, col).String())
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// -------------------------- Gaussian elimination ---------------------------
+
+func (m *Matrix) swapRows(a, b int) {
+	for col := 0; col < m.cols; col++ {
+		m.cells[a*m.cols+col], m.cells[b*m.cols+col] = m.cells[b*m.cols+col], m.cells[a*m.cols+col]
+	}
+}
+
+// reduce brings the matrix into reduced row echelon form in place, only the
+// first cols columns are used for pivots. It returns the pivot column of each
+// non zero row and the factor the determinant changed by, the product of the
+// pivots and -1 for each swap.
+func (m *Matrix) reduce(cols int) ([]int, number.Rat) {
+	var pivots []int
+	factor := number.RatOf(1)
+	row := 0
+	for col := 0; col < cols && row < m.rows; col++ {
+		// any non zero pivot will do, the numbers are exact
+		pivot := -1
+		for r := row; r < m.rows; r++ {
+			if !m.At(r, col).IsZero() {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		if pivot != row {
+			m.swapRows(pivot, row)
+			factor = factor.Neg()
+		}
+
+		value := m.At(row, col)
+		factor = factor.Mul(value)
+		for c := col; c < m.cols; c++ {
+			m.Set(row, c, m.At(row, c).Div(value))
+		}
+		for r := 0; r < m.rows; r++ {
+			if r == row || m.At(r, col).IsZero() {
+				continue
+			}
+			scale := m.At(r, col)
+			for c := col; c < m.cols; c++ {
+				m.Set(r, c, m.At(r, c).Sub(scale.Mul(m.At(row, c))))
+			}
+		}
+		pivots = append(pivots, col)
+		row++
+	}
+	return pivots, factor
+}
+
+// Reduced returns the reduced row echelon form of the matrix
+func (m *Matrix) Reduced() *Matrix {
+	result := m.Clone()
+	result.reduce(result.cols)
+	return result
+}
+
+// Rank is the number of linearly independent rows
+func (m *Matrix) Rank() int {
+	pivots, _ := m.Clone().reduce(m.cols)
+	return len(pivots)
+}
+
+// Det is the determinant of a square matrix
+func (m *Matrix) Det() number.Rat {
+	if m.rows != m.cols {
+		panic(fmt.Sprintf("linalg.Det() - %dx%d is not square", m.rows, m.cols))
+	}
+	pivots, factor := m.Clone().reduce(m.cols)
+	if len(pivots) < m.rows {
+		return number.Rat{}
+	}
+	return factor
+}
+
+// Solve returns x with m * x = b. ErrNoSolution is returned if the equations
+// contradict each other and ErrUnderdetermined if there are infinitely many
+// solutions, eg for a singular square matrix. More equations than unknowns
+// are fine as long as they agree.
+func (m *Matrix) Solve(b []number.Rat) ([]number.Rat, error) {
+	if len(b) != m.rows {
+		return nil, fmt.Errorf("linalg: %d values for %d equations", len(b), m.rows)
+	}
+	augmented := New(m.rows, m.cols+1)
+	for row := 0; row < m.rows; row++ {
+		copy(augmented.cells[row*augmented.cols:], m.cells[row*m.cols:(row+1)*m.cols])
+		augmented.Set(row, m.cols, b[row])
+	}
+
+	pivots, _ := augmented.reduce(m.cols)
+	// a row 0 = c with c not 0 is a contradiction
+	for row := len(pivots); row < m.rows; row++ {
+		if !augmented.At(row, m.cols).IsZero() {
+			return nil, ErrNoSolution
+		}
+	}
+	if len(pivots) < m.cols {
+		return nil, ErrUnderdetermined
+	}
+
+	x := make([]number.Rat, m.cols)
+	for row, col := range pivots {
+		x[col] = augmented.At(row, m.cols)
+	}
+	return x, nil
+}
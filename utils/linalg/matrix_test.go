@@ -0,0 +1,125 @@
+package linalg
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/cdr74/AdventOfCode2023/utils/number"
+)
+
+func rats(values ...int) []number.Rat {
+	result := make([]number.Rat, len(values))
+	for idx, value := range values {
+		result[idx] = number.RatOf(value)
+	}
+	return result
+}
+
+func TestDetAndRank(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix *Matrix
+		det    string
+		rank   int
+	}{
+		{"identity", Identity(3), "1", 3},
+		{"2x2", Of([][]int{{3, 8}, {4, 6}}), "-14", 2},
+		{"swap needed", Of([][]int{{0, 1}, {1, 0}}), "-1", 2},
+		{"3x3", Of([][]int{{6, 1, 1}, {4, -2, 5}, {2, 8, 7}}), "-306", 3},
+		{"singular", Of([][]int{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}}), "0", 2},
+		{"zero", New(2, 2), "0", 0},
+	}
+	for _, test := range tests {
+		if det := test.matrix.Det(); det.String() != test.det {
+			t.Errorf("%s: Expected determinant %s, but got %v", test.name, test.det, det)
+		}
+		if rank := test.matrix.Rank(); rank != test.rank {
+			t.Errorf("%s: Expected rank %d, but got %d", test.name, test.rank, rank)
+		}
+	}
+
+	if rank := Of([][]int{{1, 2, 3, 4}, {2, 4, 6, 9}}).Rank(); rank != 2 {
+		t.Errorf("Expected rank 2 of a 2x4 matrix, but got %d", rank)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// 2x + y - z = 8, -3x - y + 2z = -11, -2x + y + 2z = -3 has x=2, y=3, z=-1
+	m := Of([][]int{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}})
+	x, err := m.Solve(rats(8, -11, -3))
+	if err != nil {
+		t.Fatalf("Expected a solution, but got %v", err)
+	}
+	for idx, expected := range []string{"2", "3", "-1"} {
+		if x[idx].String() != expected {
+			t.Errorf("Expected x%d = %s, but got %v", idx, expected, x[idx])
+		}
+	}
+
+	// solutions are exact fractions
+	x, err = Of([][]int{{3, 0}, {0, 7}}).Solve(rats(1, 2))
+	if err != nil || x[0].String() != "1/3" || x[1].String() != "2/7" {
+		t.Errorf("Expected 1/3 and 2/7, but got %v, %v", x, err)
+	}
+
+	singular := Of([][]int{{1, 2}, {2, 4}})
+	if _, err := singular.Solve(rats(3, 7)); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("Expected no solution, but got %v", err)
+	}
+	if _, err := singular.Solve(rats(3, 6)); !errors.Is(err, ErrUnderdetermined) {
+		t.Errorf("Expected infinitely many solutions, but got %v", err)
+	}
+
+	// more equations than unknowns that agree
+	x, err = Of([][]int{{1, 0}, {0, 1}, {1, 1}}).Solve(rats(4, 5, 9))
+	if err != nil || x[0].String() != "4" || x[1].String() != "5" {
+		t.Errorf("Expected 4 and 5, but got %v, %v", x, err)
+	}
+}
+
+func TestSolveRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for n := 0; n < 200; n++ {
+		size := r.Intn(5) + 1
+		values := make([][]int, size)
+		for row := range values {
+			values[row] = make([]int, size)
+			for col := range values[row] {
+				values[row][col] = r.Intn(11) - 5
+			}
+		}
+		m := Of(values)
+		expected := rats(r.Perm(size)...)
+		b := m.MulVector(expected)
+
+		x, err := m.Solve(b)
+		if m.Det().IsZero() {
+			if err == nil {
+				t.Fatalf("Expected an error for singular\n%v", m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected a solution for\n%v but got %v", m, err)
+		}
+		for idx := range x {
+			if x[idx].Cmp(expected[idx]) != 0 {
+				t.Fatalf("Expected %v, but got %v for\n%v", expected, x, m)
+			}
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	a := Of([][]int{{1, 2}, {3, 4}})
+	if result := a.Mul(Identity(2)).String(); result != a.String() {
+		t.Errorf("Expected %q, but got %q", a.String(), result)
+	}
+	if result := a.Mul(a).String(); result != "7 10\n15 22\n" {
+		t.Errorf("Expected %q, but got %q", "7 10\n15 22\n", result)
+	}
+	if reduced := a.Reduced().String(); reduced != Identity(2).String() {
+		t.Errorf("Expected the identity, but got %q", reduced)
+	}
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"container/list"
+	"sync"
+)
+
+// MemoStats counts the lookups of a memo
+type MemoStats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+type memoEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Memo caches values by key, usually the results of a recursive function for
+// its arguments as a struct key. A memo with a limit evicts the least
+// recently used value once it is full. It is not safe for concurrent use,
+// see SyncMemo.
+type Memo[K comparable, V any] struct {
+	limit   int
+	entries map[K]*list.Element
+	// most recently used first, only kept with a limit
+	order *list.List
+	stats MemoStats
+}
+
+// NewMemo returns a memo that keeps all values
+func NewMemo[K comparable, V any]() *Memo[K, V] {
+	return NewBoundedMemo[K, V](0)
+}
+
+// NewBoundedMemo returns a memo that keeps up to limit values, 0 is no limit
+func NewBoundedMemo[K comparable, V any](limit int) *Memo[K, V] {
+	return &Memo[K, V]{limit: limit, entries: make(map[K]*list.Element), order: list.New()}
+}
+
+// Get returns the value of key, if there is one
+func (m *Memo[K, V]) Get(key K) (V, bool) {
+	element, ok := m.entries[key]
+	if !ok {
+		m.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	m.stats.Hits++
+	if m.limit > 0 {
+		m.order.MoveToFront(element)
+	}
+	return element.Value.(*memoEntry[K, V]).value, true
+}
+
+// Put keeps value for key, evicting the least recently used value if full
+func (m *Memo[K, V]) Put(key K, value V) {
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoEntry[K, V]).value = value
+		if m.limit > 0 {
+			m.order.MoveToFront(element)
+		}
+		return
+	}
+	entry := &memoEntry[K, V]{key: key, value: value}
+	if m.limit <= 0 {
+		m.entries[key] = &list.Element{Value: entry}
+		return
+	}
+	if m.order.Len() >= m.limit {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoEntry[K, V]).key)
+		m.stats.Evictions++
+	}
+	m.entries[key] = m.order.PushFront(entry)
+}
+
+// Do returns the value of key, compute is only called if there is none yet.
+// compute may use the memo itself, eg for recursive calls.
+func (m *Memo[K, V]) Do(key K, compute func() V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+	value := compute()
+	m.Put(key, value)
+	return value
+}
+
+func (m *Memo[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Clear removes all values, the stats are kept
+func (m *Memo[K, V]) Clear() {
+	m.entries = make(map[K]*list.Element)
+	m.order.Init()
+}
+
+func (m *Memo[K, V]) Stats() MemoStats {
+	return m.stats
+}
+
+// -------------------------- Concurrent memo --------------------------------
+
+// SyncMemo is a Memo that is safe for concurrent use. The lock is not held
+// while computing, so two goroutines may compute the same key at once, the
+// later value wins.
+type SyncMemo[K comparable, V any] struct {
+	mutex sync.Mutex
+	memo  *Memo[K, V]
+}
+
+func NewSyncMemo[K comparable, V any]() *SyncMemo[K, V] {
+	return NewBoundedSyncMemo[K, V](0)
+}
+
+func NewBoundedSyncMemo[K comparable, V any](limit int) *SyncMemo[K, V] {
+	return &SyncMemo[K, V]{memo: NewBoundedMemo[K, V](limit)}
+}
+
+func (m *SyncMemo[K, V]) Get(key K) (V, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.memo.Get(key)
+}
+
+func (m *SyncMemo[K, V]) Put(key K, value V) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.memo.Put(key, value)
+}
+
+func (m *SyncMemo[K, V]) Do(key K, compute func() V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+	value := compute()
+	m.Put(key, value)
+	return value
+}
+
+func (m *SyncMemo[K, V]) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.memo.Len()
+}
+
+func (m *SyncMemo[K, V]) Clear() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.memo.Clear()
+}
+
+func (m *SyncMemo[K, V]) Stats() MemoStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.memo.Stats()
+}
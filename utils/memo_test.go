@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+)
+
+type fibKey struct {
+	n int
+}
+
+func memoFib(memo *Memo[fibKey, int], n int) int {
+	return memo.Do(fibKey{n}, func() int {
+		if n < 2 {
+			return n
+		}
+		return memoFib(memo, n-1) + memoFib(memo, n-2)
+	})
+}
+
+func TestMemoRecursive(t *testing.T) {
+	memo := NewMemo[fibKey, int]()
+	if result := memoFib(memo, 90); result != 2880067194370816120 {
+		t.Errorf("Expected 2880067194370816120, but got %d", result)
+	}
+	// each value is computed once, every other lookup is a hit
+	stats := memo.Stats()
+	if stats.Misses != 91 || stats.Hits != 88 || memo.Len() != 91 {
+		t.Errorf("Expected 91 misses and 88 hits, but got %+v with %d values", stats, memo.Len())
+	}
+
+	memo.Clear()
+	if _, ok := memo.Get(fibKey{10}); ok || memo.Len() != 0 {
+		t.Errorf("Expected an empty memo after Clear")
+	}
+}
+
+func TestMemoEviction(t *testing.T) {
+	memo := NewBoundedMemo[string, int](2)
+	memo.Put("a", 1)
+	memo.Put("b", 2)
+	memo.Get("a")
+	// b is the least recently used
+	memo.Put("c", 3)
+
+	if _, ok := memo.Get("b"); ok {
+		t.Errorf("Expected b to be evicted")
+	}
+	for key, expected := range map[string]int{"a": 1, "c": 3} {
+		if value, ok := memo.Get(key); !ok || value != expected {
+			t.Errorf("Expected %s to be %d, but got %d", key, expected, value)
+		}
+	}
+	if memo.Len() != 2 || memo.Stats().Evictions != 1 {
+		t.Errorf("Expected 2 values and 1 eviction, but got %d and %+v", memo.Len(), memo.Stats())
+	}
+
+	memo.Put("a", 10)
+	if value, _ := memo.Get("a"); value != 10 || memo.Len() != 2 {
+		t.Errorf("Expected a to be replaced by 10, but got %d", value)
+	}
+}
+
+func TestSyncMemo(t *testing.T) {
+	memo := NewBoundedSyncMemo[int, int](50)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				if value := memo.Do(n, func() int { return n * n }); value != n*n {
+					t.Errorf("Expected %d, but got %d", n*n, value)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := memo.Stats()
+	if memo.Len() != 50 || stats.Hits+stats.Misses != 800 {
+		t.Errorf("Expected 50 values and 800 lookups, but got %d and %+v", memo.Len(), stats)
+	}
+}
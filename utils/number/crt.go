@@ -0,0 +1,60 @@
+package number
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CRT solves x = residues[i] modulo moduli[i] for all i with the chinese
+// remainder theorem. The moduli do not have to be coprime. It returns the
+// smallest x that is not negative and the lcm of the moduli, all solutions
+// are x + k*lcm. ErrNoSolution is returned if the congruences contradict
+// each other and ErrOverflow if x or lcm do not fit into T.
+func CRT[T Signed](residues []T, moduli []T) (T, T, error) {
+	x, m, err := BigCRT(residues, moduli)
+	if err != nil {
+		return 0, 0, err
+	}
+	lcm, err := fromBig[T](m)
+	if err != nil {
+		return 0, 0, err
+	}
+	result, err := fromBig[T](x)
+	return result, lcm, err
+}
+
+// BigCRT is CRT without a limit, the numbers are combined pairwise in big
+// integers so no intermediate product can overflow
+func BigCRT[T Signed](residues []T, moduli []T) (*big.Int, *big.Int, error) {
+	if len(residues) != len(moduli) {
+		return nil, nil, fmt.Errorf("number: %d residues but %d moduli", len(residues), len(moduli))
+	}
+
+	x, m := big.NewInt(0), big.NewInt(1)
+	for idx := range moduli {
+		if moduli[idx] <= 0 {
+			return nil, nil, fmt.Errorf("number: modulus must be positive, got %v", moduli[idx])
+		}
+		n := toBig(moduli[idx])
+		r := new(big.Int).Mod(toBig(residues[idx]), n)
+
+		// x + m*k = r modulo n  <=>  m/g*k = (r-x)/g modulo n/g
+		g := new(big.Int).GCD(nil, nil, m, n)
+		diff := new(big.Int).Sub(r, x)
+		if new(big.Int).Mod(diff, g).Sign() != 0 {
+			return nil, nil, fmt.Errorf("%w: x = %v modulo %v contradicts x = %v modulo %v", ErrNoSolution, r, n, x, m)
+		}
+		nG := new(big.Int).Div(n, g)
+		k := new(big.Int).Div(diff, g)
+		if nG.Cmp(big.NewInt(1)) != 0 {
+			inverse := new(big.Int).ModInverse(new(big.Int).Div(m, g), nG)
+			k.Mul(k, inverse)
+		}
+		k.Mod(k, nG)
+
+		x.Add(x, k.Mul(k, m))
+		m.Mul(m, nG)
+		x.Mod(x, m)
+	}
+	return x, m, nil
+}
@@ -0,0 +1,174 @@
+// Package number has the number theory the cycle based puzzles need: gcd,
+// lcm, extended Euclid, modular inverse and the chinese remainder theorem,
+// and exact rational numbers for the geometry puzzles. Results that do not
+// fit into the integer type are reported as ErrOverflow instead of silently
+// wrapping, the Big variants give the exact value.
+package number
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// Signed is the constraint of the operations that need negative numbers
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+var ErrOverflow = errors.New("number: result overflows")
+var ErrNotInteger = errors.New("number: not an integer")
+var ErrNotInvertible = errors.New("number: not invertible")
+var ErrNoSolution = errors.New("number: no solution")
+
+func isSigned[T utils.Integer]() bool {
+	var zero T
+	return zero-1 < zero
+}
+
+// toBig returns x as big integer
+func toBig[T utils.Integer](x T) *big.Int {
+	if isSigned[T]() {
+		return big.NewInt(int64(x))
+	}
+	return new(big.Int).SetUint64(uint64(x))
+}
+
+// fromBig returns b as T or ErrOverflow if it does not fit
+func fromBig[T utils.Integer](b *big.Int) (T, error) {
+	var result T
+	if isSigned[T]() {
+		if !b.IsInt64() || int64(T(b.Int64())) != b.Int64() {
+			return 0, fmt.Errorf("%w: %v", ErrOverflow, b)
+		}
+		result = T(b.Int64())
+	} else {
+		if !b.IsUint64() || uint64(T(b.Uint64())) != b.Uint64() {
+			return 0, fmt.Errorf("%w: %v", ErrOverflow, b)
+		}
+		result = T(b.Uint64())
+	}
+	return result, nil
+}
+
+// Add returns a + b or ErrOverflow
+func Add[T utils.Integer](a, b T) (T, error) {
+	return fromBig[T](new(big.Int).Add(toBig(a), toBig(b)))
+}
+
+// Sub returns a - b or ErrOverflow
+func Sub[T utils.Integer](a, b T) (T, error) {
+	return fromBig[T](new(big.Int).Sub(toBig(a), toBig(b)))
+}
+
+// Mul returns a * b or ErrOverflow
+func Mul[T utils.Integer](a, b T) (T, error) {
+	return fromBig[T](new(big.Int).Mul(toBig(a), toBig(b)))
+}
+
+// Gcd is the greatest common divisor using Euclid's algorithm, it is never
+// negative and Gcd(0, 0) is 0. ErrOverflow is returned if the gcd does not
+// fit into T, eg for Gcd(math.MinInt, 0).
+func Gcd[T utils.Integer](a, b T) (T, error) {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		if -a < 0 {
+			return 0, fmt.Errorf("%w: gcd of %v", ErrOverflow, a)
+		}
+		return -a, nil
+	}
+	return a, nil
+}
+
+// Lcm is the least common multiple, it is never negative and 0 if a or b is 0
+func Lcm[T utils.Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	gcd, err := Gcd(a, b)
+	if err != nil {
+		return 0, err
+	}
+	// divide first, the product itself may overflow when the lcm does not
+	lcm := new(big.Int).Mul(toBig(a/gcd), toBig(b))
+	return fromBig[T](lcm.Abs(lcm))
+}
+
+// LcmOf is the least common multiple of all values, 1 if there are none
+func LcmOf[T utils.Integer](values []T) (T, error) {
+	var result T = 1
+	for _, value := range values {
+		var err error
+		if result, err = Lcm(result, value); err != nil {
+			return 0, err
+		}
+	}
+	return result, nil
+}
+
+// BigLcmOf is LcmOf without a limit
+func BigLcmOf[T utils.Integer](values []T) *big.Int {
+	result := big.NewInt(1)
+	gcd := new(big.Int)
+	for _, value := range values {
+		b := new(big.Int).Abs(toBig(value))
+		if b.Sign() == 0 {
+			return b
+		}
+		gcd.GCD(nil, nil, result, b)
+		result.Mul(result.Div(result, gcd), b)
+	}
+	return result
+}
+
+// Mod is the remainder of a / m in 0..m-1, unlike % it is not negative for
+// a negative a. m must be positive.
+func Mod[T Signed](a, m T) T {
+	result := a % m
+	if result < 0 {
+		result += m
+	}
+	return result
+}
+
+// ExtendedGcd returns the gcd of a and b and x, y with a*x + b*y = gcd.
+// The coefficients are not larger than a and b, so they do not overflow,
+// ErrOverflow is returned if the gcd itself does not fit into T.
+func ExtendedGcd[T Signed](a, b T) (T, T, T, error) {
+	oldR, r := a, b
+	oldX, x := T(1), T(0)
+	oldY, y := T(0), T(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		if -oldR < 0 {
+			return 0, 0, 0, fmt.Errorf("%w: gcd of %v and %v", ErrOverflow, a, b)
+		}
+		return -oldR, -oldX, -oldY, nil
+	}
+	return oldR, oldX, oldY, nil
+}
+
+// ModInverse returns x in 0..m-1 with a*x = 1 modulo m, ErrNotInvertible if
+// a and m have a common divisor
+func ModInverse[T Signed](a, m T) (T, error) {
+	if m <= 0 {
+		return 0, fmt.Errorf("number: modulus must be positive, got %v", m)
+	}
+	g, x, _, err := ExtendedGcd(Mod(a, m), m)
+	if err != nil {
+		return 0, err
+	}
+	if g != 1 {
+		return 0, fmt.Errorf("%w: %v modulo %v", ErrNotInvertible, a, m)
+	}
+	return Mod(x, m), nil
+}
@@ -0,0 +1,159 @@
+package number
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGcdLcm(t *testing.T) {
+	tests := []struct {
+		a, b     int
+		gcd, lcm int
+	}{
+		{12, 18, 6, 36},
+		{-12, 18, 6, 36},
+		{7, 13, 1, 91},
+		{0, 5, 5, 0},
+		{0, 0, 0, 0},
+	}
+	for _, test := range tests {
+		if result, err := Gcd(test.a, test.b); err != nil || result != test.gcd {
+			t.Errorf("Gcd(%d, %d): Expected %d, but got %d, %v", test.a, test.b, test.gcd, result, err)
+		}
+		if result, err := Lcm(test.a, test.b); err != nil || result != test.lcm {
+			t.Errorf("Lcm(%d, %d): Expected %d, but got %d, %v", test.a, test.b, test.lcm, result, err)
+		}
+	}
+}
+
+func TestLcmOverflow(t *testing.T) {
+	// the product overflows but the lcm fits
+	large := uint64(math.MaxUint64 / 3)
+	if result, err := Lcm(large, large*2); err != nil || result != large*2 {
+		t.Errorf("Expected %d, but got %d, %v", large*2, result, err)
+	}
+
+	primes := []int32{65521, 65519, 65497}
+	if _, err := LcmOf(primes); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected an overflow, but got %v", err)
+	}
+	if result := BigLcmOf(primes).String(); result != "281170132523303" {
+		t.Errorf("Expected 281170132523303, but got %s", result)
+	}
+
+	if _, err := Mul(math.MinInt64, -1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected MinInt64 * -1 to overflow, but got %v", err)
+	}
+	if result, err := LcmOf([]int{2, 3, 4}); err != nil || result != 12 {
+		t.Errorf("Expected 12, but got %d, %v", result, err)
+	}
+}
+
+// the gcd of math.MinInt and 0 or itself is one more than math.MaxInt
+func TestGcdOverflow(t *testing.T) {
+	tests := []struct {
+		a, b int
+		gcd  int
+		ok   bool
+	}{
+		{math.MinInt, 0, 0, false},
+		{0, math.MinInt, 0, false},
+		{math.MinInt, math.MinInt, 0, false},
+		{math.MinInt, 2, 2, true},
+		{math.MinInt, -1, 1, true},
+		{math.MinInt, math.MaxInt, 1, true},
+	}
+	for _, test := range tests {
+		result, err := Gcd(test.a, test.b)
+		if test.ok && (err != nil || result != test.gcd) {
+			t.Errorf("Gcd(%d, %d): Expected %d, but got %d, %v", test.a, test.b, test.gcd, result, err)
+		}
+		if !test.ok && !errors.Is(err, ErrOverflow) {
+			t.Errorf("Gcd(%d, %d): Expected an overflow, but got %d, %v", test.a, test.b, result, err)
+		}
+
+		g, x, y, err := ExtendedGcd(test.a, test.b)
+		if test.ok && (err != nil || g != test.gcd || test.a*x+test.b*y != g) {
+			t.Errorf("ExtendedGcd(%d, %d): Expected %d = %d*x + %d*y, but got %d, %d, %d, %v", test.a, test.b, test.gcd, test.a, test.b, g, x, y, err)
+		}
+		if !test.ok && !errors.Is(err, ErrOverflow) {
+			t.Errorf("ExtendedGcd(%d, %d): Expected an overflow, but got %d, %v", test.a, test.b, g, err)
+		}
+	}
+
+	if _, err := Lcm(math.MinInt, math.MinInt); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected the lcm of MinInt and itself to overflow, but got %v", err)
+	}
+	if _, err := Gcd(uint64(math.MaxUint64), 0); err != nil {
+		t.Errorf("Expected no overflow for unsigned values, but got %v", err)
+	}
+}
+
+func TestExtendedGcdAndInverse(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for n := 0; n < 1000; n++ {
+		a, b := r.Intn(2000)-1000, r.Intn(2000)-1000
+		gcd, _ := Gcd(a, b)
+		g, x, y, err := ExtendedGcd(a, b)
+		if err != nil || g != gcd || a*x+b*y != g {
+			t.Fatalf("ExtendedGcd(%d, %d): Expected %d = %d*x + %d*y, but got %d, %d, %d, %v", a, b, gcd, a, b, g, x, y, err)
+		}
+
+		m := r.Intn(100) + 1
+		inverse, err := ModInverse(a, m)
+		if gcd, _ := Gcd(a, m); gcd != 1 {
+			if !errors.Is(err, ErrNotInvertible) {
+				t.Fatalf("ModInverse(%d, %d): Expected not invertible, but got %d, %v", a, m, inverse, err)
+			}
+		} else if err != nil || Mod(a*inverse, m) != Mod(1, m) || inverse < 0 || inverse >= m {
+			t.Fatalf("ModInverse(%d, %d): Expected an inverse, but got %d, %v", a, m, inverse, err)
+		}
+	}
+}
+
+func TestCRT(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+	for n := 0; n < 1000; n++ {
+		count := r.Intn(3) + 1
+		residues, moduli := make([]int, count), make([]int, count)
+		for idx := range moduli {
+			moduli[idx] = r.Intn(12) + 1
+			residues[idx] = r.Intn(30) - 10
+		}
+
+		// brute force the smallest solution below the lcm
+		lcm, _ := LcmOf(moduli)
+		expected := -1
+		for x := 0; x < lcm && expected < 0; x++ {
+			matches := true
+			for idx := range moduli {
+				matches = matches && Mod(x-residues[idx], moduli[idx]) == 0
+			}
+			if matches {
+				expected = x
+			}
+		}
+
+		x, m, err := CRT(residues, moduli)
+		if expected < 0 {
+			if !errors.Is(err, ErrNoSolution) {
+				t.Fatalf("CRT(%v, %v): Expected no solution, but got %d, %v", residues, moduli, x, err)
+			}
+		} else if err != nil || x != expected || m != lcm {
+			t.Fatalf("CRT(%v, %v): Expected %d modulo %d, but got %d modulo %d, %v", residues, moduli, expected, lcm, x, m, err)
+		}
+	}
+}
+
+func TestCRTOverflow(t *testing.T) {
+	moduli := []int64{1000000007, 998244353, 1000000009}
+	if _, _, err := CRT([]int64{1, 2, 3}, moduli); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected an overflow, but got %v", err)
+	}
+	x, m, err := BigCRT([]int64{1, 2, 3}, moduli)
+	if err != nil || x.Cmp(m) >= 0 || x.Sign() < 0 {
+		t.Errorf("Expected a solution below %v, but got %v, %v", m, x, err)
+	}
+}
@@ -0,0 +1,154 @@
+package number
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// ErrNoFit is returned if the samples are not on a polynomial of the degree
+var ErrNoFit = errors.New("number: samples do not fit the polynomial")
+
+// Polynomial has exact rational coefficients, the constant one first
+type Polynomial struct {
+	coefficients []Rat
+}
+
+// Fit returns the polynomial of the degree through the samples (xs[i], ys[i]).
+// It is fitted through the first degree+1 samples, the other ones are checked
+// against it and ErrNoFit is returned if any is off.
+func Fit[T utils.Integer](xs []T, ys []T, degree int) (Polynomial, error) {
+	if len(xs) != len(ys) {
+		return Polynomial{}, fmt.Errorf("number: %d x values but %d y values", len(xs), len(ys))
+	}
+	if degree < 0 || len(xs) < degree+1 {
+		return Polynomial{}, fmt.Errorf("number: %d samples are too few for degree %d", len(xs), degree)
+	}
+	x := make([]Rat, len(xs))
+	y := make([]Rat, len(ys))
+	for idx := range xs {
+		x[idx], y[idx] = RatOf(xs[idx]), RatOf(ys[idx])
+	}
+
+	p, err := newton(x[:degree+1], y[:degree+1])
+	if err != nil {
+		return Polynomial{}, err
+	}
+	for idx := degree + 1; idx < len(x); idx++ {
+		if value := p.At(x[idx]); value.Cmp(y[idx]) != 0 {
+			return Polynomial{}, fmt.Errorf("%w: degree %d gives %v at %v instead of %v", ErrNoFit, degree, value, x[idx], y[idx])
+		}
+	}
+	return p, nil
+}
+
+// FitSequence is Fit for the samples at x = 0, 1, 2, ...
+func FitSequence[T utils.Integer](ys []T, degree int) (Polynomial, error) {
+	xs := make([]T, len(ys))
+	for idx := range xs {
+		xs[idx] = T(idx)
+	}
+	return Fit(xs, ys, degree)
+}
+
+// newton interpolates with divided differences, the Newton form
+// c0 + c1(x-x0) + c2(x-x0)(x-x1) + ... is then multiplied out
+func newton(x []Rat, y []Rat) (Polynomial, error) {
+	diffs := append([]Rat{}, y...)
+	newtonCoefficients := []Rat{diffs[0]}
+	for level := 1; level < len(x); level++ {
+		for idx := len(x) - 1; idx >= level; idx-- {
+			dx := x[idx].Sub(x[idx-level])
+			if dx.IsZero() {
+				return Polynomial{}, fmt.Errorf("number: x value %v is sampled twice", x[idx])
+			}
+			diffs[idx] = diffs[idx].Sub(diffs[idx-1]).Div(dx)
+		}
+		newtonCoefficients = append(newtonCoefficients, diffs[level])
+	}
+
+	// Horner from the highest coefficient, p = p*(x - x[idx]) + c[idx]
+	coefficients := []Rat{newtonCoefficients[len(x)-1]}
+	for idx := len(x) - 2; idx >= 0; idx-- {
+		next := make([]Rat, len(coefficients)+1)
+		for power, c := range coefficients {
+			next[power+1] = next[power+1].Add(c)
+			next[power] = next[power].Sub(c.Mul(x[idx]))
+		}
+		next[0] = next[0].Add(newtonCoefficients[idx])
+		coefficients = next
+	}
+	return Polynomial{coefficients: coefficients}.trim(), nil
+}
+
+// drops leading zero coefficients
+func (p Polynomial) trim() Polynomial {
+	n := len(p.coefficients)
+	for n > 0 && p.coefficients[n-1].IsZero() {
+		n--
+	}
+	return Polynomial{coefficients: p.coefficients[:n]}
+}
+
+// Degree is the highest power with a coefficient that is not 0, -1 for the
+// zero polynomial
+func (p Polynomial) Degree() int {
+	return len(p.coefficients) - 1
+}
+
+// Coefficient returns the coefficient of x^power
+func (p Polynomial) Coefficient(power int) Rat {
+	if power < 0 || power >= len(p.coefficients) {
+		return Rat{}
+	}
+	return p.coefficients[power]
+}
+
+// At evaluates the polynomial at x with Horner's method
+func (p Polynomial) At(x Rat) Rat {
+	var result Rat
+	for idx := len(p.coefficients) - 1; idx >= 0; idx-- {
+		result = result.Mul(x).Add(p.coefficients[idx])
+	}
+	return result
+}
+
+// AtInt evaluates the polynomial at the integer x
+func AtInt[T utils.Integer](p Polynomial, x T) Rat {
+	return p.At(RatOf(x))
+}
+
+// String returns eg "1/2x^2 - 3x + 1"
+func (p Polynomial) String() string {
+	if len(p.coefficients) == 0 {
+		return "0"
+	}
+	var sb strings.Builder
+	for power := len(p.coefficients) - 1; power >= 0; power-- {
+		c := p.coefficients[power]
+		if c.IsZero() {
+			continue
+		}
+		switch {
+		case sb.Len() == 0 && c.Sign() < 0:
+			sb.WriteString("-")
+		case sb.Len() > 0 && c.Sign() < 0:
+			sb.WriteString(" - ")
+		case sb.Len() > 0:
+			sb.WriteString(" + ")
+		}
+		abs := c.Abs()
+		if power == 0 || abs.Cmp(RatOf(1)) != 0 {
+			sb.WriteString(abs.String())
+		}
+		if power > 0 {
+			sb.WriteString("x")
+		}
+		if power > 1 {
+			fmt.Fprintf(&sb, "^%d", power)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package number
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFitSequence(t *testing.T) {
+	tests := []struct {
+		samples  []int
+		degree   int
+		expected string
+		next     string
+		previous string
+	}{
+		{[]int{0, 3, 6, 9, 12, 15}, 5, "3x", "18", "-3"},
+		{[]int{1, 3, 6, 10, 15, 21}, 5, "1/2x^2 + 3/2x + 1", "28", "0"},
+		{[]int{10, 13, 16, 21, 30, 45}, 5, "1/3x^3 - x^2 + 11/3x + 10", "68", "5"},
+		{[]int{7, 7, 7}, 2, "7", "7", "7"},
+		{[]int{0, 0}, 1, "0", "0", "0"},
+	}
+	for _, test := range tests {
+		p, err := FitSequence(test.samples, test.degree)
+		if err != nil {
+			t.Fatalf("%v: Expected a fit, but got %v", test.samples, err)
+		}
+		if p.String() != test.expected {
+			t.Errorf("%v: Expected %s, but got %s", test.samples, test.expected, p)
+		}
+		next, previous := AtInt(p, len(test.samples)), AtInt(p, -1)
+		if next.String() != test.next || previous.String() != test.previous {
+			t.Errorf("%v: Expected %s and %s, but got %v and %v", test.samples, test.next, test.previous, next, previous)
+		}
+	}
+}
+
+func TestFitHugeAndErrors(t *testing.T) {
+	// 3821 + 15010n + 14904n^2 at n = 202300 does not fit into float64 exactly
+	p, err := Fit([]int{0, 1, 2, 3}, []int{3821, 33735, 93457, 182987}, 2)
+	if err != nil {
+		t.Fatalf("Expected a fit, but got %v", err)
+	}
+	if result, err := Int[int64](AtInt(p, 202300)); err != nil || result != 609953558686821 {
+		t.Errorf("Expected 609953558686821, but got %d, %v", result, err)
+	}
+	if p.Degree() != 2 || p.Coefficient(2).String() != "14904" || !p.Coefficient(3).IsZero() {
+		t.Errorf("Expected 14904x^2 as highest power, but got %s", p)
+	}
+
+	if _, err := FitSequence([]int{1, 2, 4, 8}, 2); !errors.Is(err, ErrNoFit) {
+		t.Errorf("Expected powers of 2 not to fit degree 2, but got %v", err)
+	}
+	if _, err := Fit([]int{1, 1}, []int{2, 3}, 1); err == nil {
+		t.Errorf("Expected an error for the same x twice")
+	}
+	if _, err := FitSequence([]int{1, 2}, 2); err == nil {
+		t.Errorf("Expected an error for too few samples")
+	}
+}
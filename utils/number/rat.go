@@ -0,0 +1,109 @@
+package number
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cdr74/AdventOfCode2023/utils"
+)
+
+// Rat is an exact rational number. Unlike big.Rat it is a value, operations
+// return a new Rat and never change their operands, so it can be used like
+// the builtin number types. The zero value is 0.
+type Rat struct {
+	value *big.Rat
+}
+
+// RatOf returns x as rational number
+func RatOf[T utils.Integer](x T) Rat {
+	return Rat{value: new(big.Rat).SetInt(toBig(x))}
+}
+
+// NewRat returns num / den, den must not be 0
+func NewRat[T utils.Integer](num, den T) Rat {
+	return Rat{value: new(big.Rat).SetFrac(toBig(num), toBig(den))}
+}
+
+// RatOfBig returns the big integer as rational number
+func RatOfBig(x *big.Int) Rat {
+	return Rat{value: new(big.Rat).SetInt(x)}
+}
+
+func (r Rat) big() *big.Rat {
+	if r.value == nil {
+		return new(big.Rat)
+	}
+	return r.value
+}
+
+func (r Rat) Add(other Rat) Rat {
+	return Rat{value: new(big.Rat).Add(r.big(), other.big())}
+}
+
+func (r Rat) Sub(other Rat) Rat {
+	return Rat{value: new(big.Rat).Sub(r.big(), other.big())}
+}
+
+func (r Rat) Mul(other Rat) Rat {
+	return Rat{value: new(big.Rat).Mul(r.big(), other.big())}
+}
+
+// Div returns r / other, it panics if other is 0 like an integer division
+func (r Rat) Div(other Rat) Rat {
+	return Rat{value: new(big.Rat).Quo(r.big(), other.big())}
+}
+
+func (r Rat) Neg() Rat {
+	return Rat{value: new(big.Rat).Neg(r.big())}
+}
+
+func (r Rat) Abs() Rat {
+	return Rat{value: new(big.Rat).Abs(r.big())}
+}
+
+// Cmp returns -1, 0 or +1 for r < other, r == other and r > other
+func (r Rat) Cmp(other Rat) int {
+	return r.big().Cmp(other.big())
+}
+
+// Sign returns -1, 0 or +1 for r < 0, r == 0 and r > 0
+func (r Rat) Sign() int {
+	return r.big().Sign()
+}
+
+func (r Rat) IsZero() bool {
+	return r.Sign() == 0
+}
+
+func (r Rat) IsInt() bool {
+	return r.big().IsInt()
+}
+
+// Num and Denom return the reduced numerator and the positive denominator
+func (r Rat) Num() *big.Int {
+	return new(big.Int).Set(r.big().Num())
+}
+
+func (r Rat) Denom() *big.Int {
+	return new(big.Int).Set(r.big().Denom())
+}
+
+// Int returns r as T, ErrNotInteger if it is not a whole number and
+// ErrOverflow if it does not fit
+func Int[T utils.Integer](r Rat) (T, error) {
+	if !r.IsInt() {
+		return 0, fmt.Errorf("%w: %v", ErrNotInteger, r)
+	}
+	return fromBig[T](r.big().Num())
+}
+
+// Float64 returns the nearest float64, only for output and estimates
+func (r Rat) Float64() float64 {
+	f, _ := r.big().Float64()
+	return f
+}
+
+// String returns "a/b" or "a" if r is an integer
+func (r Rat) String() string {
+	return r.big().RatString()
+}
@@ -0,0 +1,70 @@
+package number
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRatArithmetic(t *testing.T) {
+	third := NewRat(1, 3)
+	tests := []struct {
+		name     string
+		result   Rat
+		expected string
+	}{
+		{"add", third.Add(NewRat(1, 6)), "1/2"},
+		{"sub", third.Sub(RatOf(1)), "-2/3"},
+		{"mul", third.Mul(RatOf(6)), "2"},
+		{"div", third.Div(NewRat(-2, 9)), "-3/2"},
+		{"neg abs", third.Neg().Abs(), "1/3"},
+		{"zero value", Rat{}.Add(third), "1/3"},
+	}
+	for _, test := range tests {
+		if test.result.String() != test.expected {
+			t.Errorf("%s: Expected %s, but got %s", test.name, test.expected, test.result)
+		}
+	}
+
+	// operands are not changed
+	if third.String() != "1/3" {
+		t.Errorf("Expected 1/3 unchanged, but got %s", third)
+	}
+	if third.Cmp(NewRat(2, 6)) != 0 || third.Cmp(NewRat(1, 2)) >= 0 || (Rat{}).Sign() != 0 || !(Rat{}).IsZero() {
+		t.Errorf("Expected 1/3 = 2/6 < 1/2 and a zero value of 0")
+	}
+}
+
+func TestRatExact(t *testing.T) {
+	// float64 has 53 bits, these differ in the last digit
+	large := RatOf(int64(400000000000001))
+	product := large.Mul(large).Sub(RatOf(int64(400000000000000)).Mul(RatOf(int64(400000000000002))))
+	if product.String() != "1" {
+		t.Errorf("Expected 1, but got %s", product)
+	}
+
+	if result, err := Int[int64](NewRat(10, 5)); err != nil || result != 2 {
+		t.Errorf("Expected 2, but got %d, %v", result, err)
+	}
+	if _, err := Int[int64](NewRat(1, 2)); !errors.Is(err, ErrNotInteger) || errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected 1/2 not to be an integer, but got %v", err)
+	}
+	if _, err := Int[int8](RatOf(200)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected an overflow for 200 as int8, but got %v", err)
+	}
+	if f := NewRat(1, 4).Float64(); f != 0.25 {
+		t.Errorf("Expected 0.25, but got %f", f)
+	}
+}
+
+func TestCheckedAddSub(t *testing.T) {
+	if _, err := Add(int64(math.MaxInt64), 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected MaxInt64 + 1 to overflow, but got %v", err)
+	}
+	if _, err := Sub(uint(0), 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected 0 - 1 to overflow for uint, but got %v", err)
+	}
+	if result, err := Sub(5, 7); err != nil || result != -2 {
+		t.Errorf("Expected -2, but got %d, %v", result, err)
+	}
+}
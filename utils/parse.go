@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError is a problem in a puzzle input with its position, line and
+// column count from 1, a column of 0 means the whole line
+type ParseError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Line is the parse context of one input line, it adds the position to the
+// errors of the conversions
+type Line struct {
+	Index  int // 0 based index into the input lines
+	Text   string
+	Offset int // 0 based column of Text in the input line if it is only a part of it
+}
+
+func NewLine(index int, text string) Line {
+	return Line{Index: index, Text: text}
+}
+
+// Errorf returns a ParseError for the whole line
+func (l Line) Errorf(format string, args ...any) error {
+	return &ParseError{Line: l.Index + 1, Err: fmt.Errorf(format, args...)}
+}
+
+// ErrorfAt returns a ParseError at a 0 based offset into Text
+func (l Line) ErrorfAt(offset int, format string, args ...any) error {
+	return &ParseError{Line: l.Index + 1, Column: l.Offset + offset + 1, Err: fmt.Errorf(format, args...)}
+}
+
+// Slice returns Text[start:end] as a Line that keeps its position
+func (l Line) Slice(start int, end int) Line {
+	return Line{Index: l.Index, Text: l.Text[start:end], Offset: l.Offset + start}
+}
+
+// Split is strings.Split on Text, the parts keep their position
+func (l Line) Split(sep string) []Line {
+	var parts []Line
+	start := 0
+	for _, text := range strings.Split(l.Text, sep) {
+		parts = append(parts, l.Slice(start, start+len(text)))
+		start += len(text) + len(sep)
+	}
+	return parts
+}
+
+// TrimSpace removes leading and trailing blanks and keeps the position
+func (l Line) TrimSpace() Line {
+	trimmed := strings.TrimLeft(l.Text, " \t")
+	start := len(l.Text) - len(trimmed)
+	trimmed = strings.TrimRight(trimmed, " \t")
+	return l.Slice(start, start+len(trimmed))
+}
+
+// Wrap adds the line to err, errors that already have a position are returned as they are
+func (l Line) Wrap(err error) error {
+	var parseErr *ParseError
+	if err == nil || errors.As(err, &parseErr) {
+		return err
+	}
+	return &ParseError{Line: l.Index + 1, Err: err}
+}
+
+// Fields is strings.Fields on Text, the fields keep their position
+func (l Line) Fields() []Line {
+	var fields []Line
+	start := -1
+	for idx := 0; idx <= len(l.Text); idx++ {
+		blank := idx == len(l.Text) || l.Text[idx] == ' ' || l.Text[idx] == '\t'
+		if blank && start >= 0 {
+			fields = append(fields, l.Slice(start, idx))
+			start = -1
+		} else if !blank && start < 0 {
+			start = idx
+		}
+	}
+	return fields
+}
+
+// column of a field that starts at the 0 based offset into Text, leading
+// blanks of the field are skipped
+func (l Line) column(offset int, field string) int {
+	return l.Offset + offset + len(field) - len(strings.TrimLeft(field, " ")) + 1
+}
+
+// Int converts a field that starts at the 0 based offset into Text, an error
+// is reported at the field's column
+func (l Line) Int(offset int, field string) (int, error) {
+	i, err := StringToInt(field)
+	if err != nil {
+		return 0, &ParseError{Line: l.Index + 1, Column: l.column(offset, field), Err: err}
+	}
+	return i, nil
+}
+
+// Uint64 converts a field that starts at the 0 based offset into Text, an
+// error is reported at the field's column
+func (l Line) Uint64(offset int, field string) (uint64, error) {
+	i, err := StringToUint64(field)
+	if err != nil {
+		return 0, &ParseError{Line: l.Index + 1, Column: l.column(offset, field), Err: err}
+	}
+	return i, nil
+}
+
+// CheckGrid returns an error if the input is empty or its lines differ in length
+func CheckGrid(input []string) error {
+	if len(input) == 0 || len(input[0]) == 0 {
+		return fmt.Errorf("empty input")
+	}
+	for idx, line := range input {
+		if len(line) != len(input[0]) {
+			return NewLine(idx, line).Errorf("expected %d characters, got %d", len(input[0]), len(line))
+		}
+	}
+	return nil
+}
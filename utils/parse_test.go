@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLineIntReportsPosition(t *testing.T) {
+	tests := []struct {
+		text   string
+		offset int
+		field  string
+		column int
+	}{
+		{"Card 1: 41 4x | 83", 11, "4x", 12},
+		{"Card 1: 4x 4x | 83", 11, "4x", 12}, // same text earlier in the line
+		{"Card 1: 41 | 83  x", 15, "  x", 18},
+	}
+
+	for _, test := range tests {
+		line := NewLine(2, test.text)
+		_, err := line.Int(test.offset, test.field)
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected a ParseError, but got %v", err)
+		}
+		if parseErr.Line != 3 || parseErr.Column != test.column {
+			t.Errorf("%q: Expected line 3, column %d, but got %v", test.text, test.column, err)
+		}
+	}
+}
+
+func TestLineFields(t *testing.T) {
+	line := Line{Index: 0, Text: " 32T3K  765 ", Offset: 4}
+	fields := line.Fields()
+
+	if len(fields) != 2 || fields[0].Text != "32T3K" || fields[1].Text != "765" {
+		t.Fatalf("Expected fields 32T3K and 765, but got %v", fields)
+	}
+	if fields[0].Offset != 5 || fields[1].Offset != 12 {
+		t.Errorf("Expected offsets 5 and 12, but got %d and %d", fields[0].Offset, fields[1].Offset)
+	}
+}
+
+func TestReadDataFileLongLine(t *testing.T) {
+	// longer than the default bufio.Scanner limit of 64k
+	long := strings.Repeat("rn=1,", 20000)
+	filename := filepath.Join(t.TempDir(), "long.data")
+	if err := os.WriteFile(filename, []byte(long+"\nsecond\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := ReadDataFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[0] != long {
+		t.Errorf("Expected the long line and a second one, but got %d lines", len(lines))
+	}
+}
+
+func TestReadDataFileMissing(t *testing.T) {
+	if _, err := ReadDataFile(filepath.Join(t.TempDir(), "missing.data")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,87 @@
+package utils
+
+// Edge is a step to a neighbouring state and what it costs, costs must not be negative
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Path is the result of a shortest path search, States runs from the start to
+// the goal including both
+type Path[S comparable] struct {
+	Cost   int
+	States []S
+}
+
+// queued state with the cost to reach it and the estimated total cost
+type pathItem[S comparable] struct {
+	state    S
+	cost     int
+	estimate int
+}
+
+// ShortestPath finds the cheapest way from one of the starts to a state for
+// which isGoal is true with Dijkstra's algorithm. next returns the states
+// reachable from a state, ok is false if no goal can be reached.
+func ShortestPath[S comparable](starts []S, next func(S) []Edge[S], isGoal func(S) bool) (Path[S], bool) {
+	return AStar(starts, next, isGoal, nil)
+}
+
+// AStar is ShortestPath guided by a heuristic that estimates the remaining
+// cost to a goal. The heuristic must never overestimate and not drop by more
+// than the cost of an edge, otherwise the path found may not be the cheapest.
+// A nil heuristic is Dijkstra's algorithm.
+func AStar[S comparable](starts []S, next func(S) []Edge[S], isGoal func(S) bool, heuristic func(S) int) (Path[S], bool) {
+	estimate := func(state S, cost int) int {
+		if heuristic == nil {
+			return cost
+		}
+		return cost + heuristic(state)
+	}
+
+	queue := NewPriorityQueue(func(a, b pathItem[S]) bool { return a.estimate < b.estimate })
+	costs := make(map[S]int)
+	previous := make(map[S]S)
+	done := make(map[S]bool)
+
+	for _, start := range starts {
+		costs[start] = 0
+		queue.Push(pathItem[S]{state: start, estimate: estimate(start, 0)})
+	}
+
+	for queue.Len() > 0 {
+		item, _ := queue.Pop()
+		if done[item.state] || item.cost > costs[item.state] {
+			// outdated entry, the state was queued again with a lower cost
+			continue
+		}
+		done[item.state] = true
+
+		if isGoal(item.state) {
+			return Path[S]{Cost: item.cost, States: tracePath(previous, item.state)}, true
+		}
+
+		for _, edge := range next(item.state) {
+			cost := item.cost + edge.Cost
+			if known, ok := costs[edge.To]; ok && known <= cost {
+				continue
+			}
+			costs[edge.To] = cost
+			previous[edge.To] = item.state
+			queue.Push(pathItem[S]{state: edge.To, cost: cost, estimate: estimate(edge.To, cost)})
+		}
+	}
+	return Path[S]{}, false
+}
+
+// walks back from the goal, starts are the states without a predecessor
+func tracePath[S comparable](previous map[S]S, goal S) []S {
+	path := []S{goal}
+	for state, ok := previous[goal]; ok; state, ok = previous[state] {
+		path = append(path, state)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+type cell struct {
+	row int
+	col int
+}
+
+var pathMaze = []string{
+	"S.#....",
+	".##.##.",
+	"...#...",
+	"#.....E",
+}
+
+// moves to the free cells around, entering a cell costs 1
+func mazeNext(maze []string) func(cell) []Edge[cell] {
+	return func(c cell) []Edge[cell] {
+		var edges []Edge[cell]
+		for _, d := range []cell{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+			n := cell{c.row + d.row, c.col + d.col}
+			if n.row >= 0 && n.row < len(maze) && n.col >= 0 && n.col < len(maze[0]) && maze[n.row][n.col] != '#' {
+				edges = append(edges, Edge[cell]{To: n, Cost: 1})
+			}
+		}
+		return edges
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	isEnd := func(c cell) bool { return c == cell{3, 6} }
+	path, ok := ShortestPath([]cell{{0, 0}}, mazeNext(pathMaze), isEnd)
+	if !ok {
+		t.Fatalf("Expected a path")
+	}
+	if path.Cost != 9 {
+		t.Errorf("Expected 9, but got %d", path.Cost)
+	}
+	if len(path.States) != path.Cost+1 || path.States[0] != (cell{0, 0}) || path.States[len(path.States)-1] != (cell{3, 6}) {
+		t.Errorf("Expected a path of 10 cells from start to end, but got %v", path.States)
+	}
+	for idx := 1; idx < len(path.States); idx++ {
+		a, b := path.States[idx-1], path.States[idx]
+		if Abs(a.row-b.row)+Abs(a.col-b.col) != 1 {
+			t.Errorf("Expected neighbouring cells, but got %v and %v", a, b)
+		}
+	}
+}
+
+func TestAStarMatchesDijkstra(t *testing.T) {
+	end := cell{3, 6}
+	isEnd := func(c cell) bool { return c == end }
+	manhattan := func(c cell) int { return Abs(c.row-end.row) + Abs(c.col-end.col) }
+
+	dijkstra, _ := ShortestPath([]cell{{0, 0}}, mazeNext(pathMaze), isEnd)
+	astar, ok := AStar([]cell{{0, 0}}, mazeNext(pathMaze), isEnd, manhattan)
+	if !ok || astar.Cost != dijkstra.Cost {
+		t.Errorf("Expected %d, but got %d", dijkstra.Cost, astar.Cost)
+	}
+}
+
+func TestShortestPathWeighted(t *testing.T) {
+	// the direct edge costs more than the detour
+	edges := map[string][]Edge[string]{
+		"a": {{To: "d", Cost: 10}, {To: "b", Cost: 2}},
+		"b": {{To: "c", Cost: 3}},
+		"c": {{To: "d", Cost: 1}},
+	}
+	next := func(s string) []Edge[string] { return edges[s] }
+	path, ok := ShortestPath([]string{"a"}, next, func(s string) bool { return s == "d" })
+	if !ok || path.Cost != 6 || !reflect.DeepEqual(path.States, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected a b c d with cost 6, but got %v with cost %d", path.States, path.Cost)
+	}
+
+	// several starts, the goal is one of them
+	path, _ = ShortestPath([]string{"x", "c"}, next, func(s string) bool { return s == "c" })
+	if path.Cost != 0 || !reflect.DeepEqual(path.States, []string{"c"}) {
+		t.Errorf("Expected c with cost 0, but got %v with cost %d", path.States, path.Cost)
+	}
+}
+
+func TestShortestPathUnreachable(t *testing.T) {
+	blocked := []string{
+		"S#.",
+		"##E",
+	}
+	_, ok := ShortestPath([]cell{{0, 0}}, mazeNext(blocked), func(c cell) bool { return c == cell{1, 2} })
+	if ok {
+		t.Errorf("Expected no path")
+	}
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Section is a block of input lines separated from the others by blank lines.
+// A first line ending with ':' like "seed-to-soil map:" is the Header and not
+// part of Lines.
+type Section struct {
+	Header string
+	Lines  []string
+	First  int // 0 based index of the first line, the header if there is one
+}
+
+// Line returns the parse context of line idx of the section
+func (s Section) Line(idx int) Line {
+	if s.Header != "" {
+		return NewLine(s.First+1+idx, s.Lines[idx])
+	}
+	return NewLine(s.First+idx, s.Lines[idx])
+}
+
+// HeaderLine returns the parse context of the header
+func (s Section) HeaderLine() Line {
+	return NewLine(s.First, s.Header)
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// collects lines into sections, add returns a section once a blank line ends it
+type sectionSplitter struct {
+	current Section
+	open    bool
+}
+
+func (s *sectionSplitter) add(idx int, line string) (Section, bool) {
+	if isBlank(line) {
+		return s.flush()
+	}
+	if !s.open {
+		s.current = Section{First: idx}
+		s.open = true
+		if strings.HasSuffix(line, ":") {
+			s.current.Header = line
+			return Section{}, false
+		}
+	}
+	s.current.Lines = append(s.current.Lines, line)
+	return Section{}, false
+}
+
+func (s *sectionSplitter) flush() (Section, bool) {
+	if !s.open {
+		return Section{}, false
+	}
+	s.open = false
+	return s.current, true
+}
+
+// Sections splits the input at blank lines, several blank lines in a row
+// separate like one and leading or trailing blank lines are ignored
+func Sections(input []string) []Section {
+	var sections []Section
+	var splitter sectionSplitter
+	for idx, line := range input {
+		if section, ok := splitter.add(idx, line); ok {
+			sections = append(sections, section)
+		}
+	}
+	if section, ok := splitter.flush(); ok {
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// SectionReader streams the sections of a reader without reading it all
+// into memory, use it like a bufio.Scanner:
+//
+//	reader := utils.NewSectionReader(file)
+//	for reader.Next() {
+//		section := reader.Section()
+//	}
+//	err := reader.Err()
+type SectionReader struct {
+	scanner  *bufio.Scanner
+	splitter sectionSplitter
+	index    int
+	section  Section
+	done     bool
+}
+
+func NewSectionReader(r io.Reader) *SectionReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MAX_LINE_LENGTH)
+	return &SectionReader{scanner: scanner}
+}
+
+// Next reads the next section, it returns false at the end of the input or on an error
+func (r *SectionReader) Next() bool {
+	if r.done {
+		return false
+	}
+	for r.scanner.Scan() {
+		section, ok := r.splitter.add(r.index, r.scanner.Text())
+		r.index++
+		if ok {
+			r.section = section
+			return true
+		}
+	}
+
+	r.done = true
+	if r.scanner.Err() != nil {
+		return false
+	}
+	section, ok := r.splitter.flush()
+	r.section = section
+	return ok
+}
+
+// Section returns the section read by the last call of Next
+func (r *SectionReader) Section() Section {
+	return r.section
+}
+
+// Err returns the first error of the underlying reader
+func (r *SectionReader) Err() error {
+	if err := r.scanner.Err(); err != nil {
+		return &ParseError{Line: r.index + 1, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var sectionInput = []string{
+	"seeds: 79 14",
+	"",
+	"seed-to-soil map:",
+	"50 98 2",
+	"52 50 48",
+	"",
+	"",
+	"#.##..##.",
+	"..#.##.#.",
+	"",
+}
+
+func TestSections(t *testing.T) {
+	expected := []Section{
+		{Lines: []string{"seeds: 79 14"}, First: 0},
+		{Header: "seed-to-soil map:", Lines: []string{"50 98 2", "52 50 48"}, First: 2},
+		{Lines: []string{"#.##..##.", "..#.##.#."}, First: 7},
+	}
+
+	result := Sections(sectionInput)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, result)
+	}
+
+	line := result[1].Line(1)
+	if line.Index != 4 || line.Text != "52 50 48" {
+		t.Errorf("Expected line index 4 of the input, but got %d %q", line.Index, line.Text)
+	}
+}
+
+func TestSectionReaderMatchesSections(t *testing.T) {
+	reader := NewSectionReader(strings.NewReader(strings.Join(sectionInput, "\n")))
+	var result []Section
+	for reader.Next() {
+		result = append(result, reader.Section())
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Sections(sectionInput)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestSectionsEmpty(t *testing.T) {
+	if result := Sections([]string{"", "  "}); len(result) != 0 {
+		t.Errorf("Expected no sections, but got %v", result)
+	}
+}
+
+func FuzzSections(f *testing.F) {
+	f.Add(strings.Join(sectionInput, "\n"))
+	f.Add("header:\n\n\n  \nlast")
+	f.Fuzz(func(t *testing.T, text string) {
+		reader := NewSectionReader(strings.NewReader(text))
+		var result []Section
+		for reader.Next() {
+			result = append(result, reader.Section())
+		}
+		if reader.Err() != nil {
+			t.Skip(reader.Err())
+		}
+
+		lines, _ := ReadLines(strings.NewReader(text), "fuzz")
+		if expected := Sections(lines); !reflect.DeepEqual(result, expected) {
+			t.Errorf("%q: expected %v, but got %v", text, expected, result)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package utils
+
+// DisjointSet is a union-find of comparable elements with path compression
+// and union by rank. Elements are added on first use, each one starts as a
+// component of its own.
+type DisjointSet[K comparable] struct {
+	index    map[K]int
+	elements []K
+	parent   []int
+	rank     []int
+	size     []int
+	count    int
+}
+
+func NewDisjointSet[K comparable]() *DisjointSet[K] {
+	return &DisjointSet[K]{index: make(map[K]int)}
+}
+
+// Add adds the element as a component of its own, if it is new
+func (d *DisjointSet[K]) Add(element K) {
+	d.id(element)
+}
+
+// returns the index of the element, adds it if it is new
+func (d *DisjointSet[K]) id(element K) int {
+	if idx, ok := d.index[element]; ok {
+		return idx
+	}
+	idx := len(d.elements)
+	d.index[element] = idx
+	d.elements = append(d.elements, element)
+	d.parent = append(d.parent, idx)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	return idx
+}
+
+// root of the index, each visited index is linked to the root directly
+func (d *DisjointSet[K]) root(idx int) int {
+	root := idx
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	for d.parent[idx] != root {
+		d.parent[idx], idx = root, d.parent[idx]
+	}
+	return root
+}
+
+// Find returns the representative of the component of the element
+func (d *DisjointSet[K]) Find(element K) K {
+	return d.elements[d.root(d.id(element))]
+}
+
+// Union merges the components of a and b, it returns false if they were
+// one component already
+func (d *DisjointSet[K]) Union(a, b K) bool {
+	rootA, rootB := d.root(d.id(a)), d.root(d.id(b))
+	if rootA == rootB {
+		return false
+	}
+	// the lower tree is hung below the higher one
+	if d.rank[rootA] < d.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	d.parent[rootB] = rootA
+	d.size[rootA] += d.size[rootB]
+	if d.rank[rootA] == d.rank[rootB] {
+		d.rank[rootA]++
+	}
+	d.count--
+	return true
+}
+
+// Connected is true if a and b are in the same component
+func (d *DisjointSet[K]) Connected(a, b K) bool {
+	return d.root(d.id(a)) == d.root(d.id(b))
+}
+
+// Size returns the number of elements in the component of the element
+func (d *DisjointSet[K]) Size(element K) int {
+	return d.size[d.root(d.id(element))]
+}
+
+// Len returns the number of elements
+func (d *DisjointSet[K]) Len() int {
+	return len(d.elements)
+}
+
+// Count returns the number of components
+func (d *DisjointSet[K]) Count() int {
+	return d.count
+}
+
+// Components returns the elements of each component, components are in the
+// order of their first added element and elements in the order they were added
+func (d *DisjointSet[K]) Components() [][]K {
+	position := make(map[int]int)
+	var result [][]K
+	for idx, element := range d.elements {
+		root := d.root(idx)
+		pos, ok := position[root]
+		if !ok {
+			pos = len(result)
+			position[root] = pos
+			result = append(result, nil)
+		}
+		result[pos] = append(result[pos], element)
+	}
+	return result
+}
+
+// Sizes returns the size of each component in the order of Components
+func (d *DisjointSet[K]) Sizes() []int {
+	components := d.Components()
+	result := make([]int, len(components))
+	for idx, component := range components {
+		result[idx] = len(component)
+	}
+	return result
+}
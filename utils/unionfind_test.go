@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDisjointSet(t *testing.T) {
+	d := NewDisjointSet[string]()
+	for _, element := range []string{"a", "b", "c", "d", "e"} {
+		d.Add(element)
+	}
+	if !d.Union("a", "b") || !d.Union("d", "c") || !d.Union("b", "d") {
+		t.Errorf("Expected unions of different components to merge them")
+	}
+	if d.Union("a", "c") {
+		t.Errorf("Expected a and c to be one component already")
+	}
+
+	if !d.Connected("a", "c") || d.Connected("a", "e") {
+		t.Errorf("Expected a, b, c, d to be connected and e apart")
+	}
+	if d.Find("c") != d.Find("b") {
+		t.Errorf("Expected the same representative for b and c, but got %s and %s", d.Find("b"), d.Find("c"))
+	}
+	if d.Size("a") != 4 || d.Size("e") != 1 || d.Count() != 2 || d.Len() != 5 {
+		t.Errorf("Expected sizes 4 and 1 in 2 components, but got %d, %d, %d", d.Size("a"), d.Size("e"), d.Count())
+	}
+
+	expected := [][]string{{"a", "b", "c", "d"}, {"e"}}
+	if !reflect.DeepEqual(d.Components(), expected) {
+		t.Errorf("Expected %v, but got %v", expected, d.Components())
+	}
+	if !reflect.DeepEqual(d.Sizes(), []int{4, 1}) {
+		t.Errorf("Expected [4 1], but got %v", d.Sizes())
+	}
+
+	// unknown elements are added on first use
+	if d.Connected("x", "y") || d.Len() != 7 || d.Count() != 4 {
+		t.Errorf("Expected x and y as components of their own, but got %d elements in %d components", d.Len(), d.Count())
+	}
+}
+
+// compares with components labelled by flooding the union edges
+func TestDisjointSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	for n := 0; n < 100; n++ {
+		size := r.Intn(30) + 1
+		d := NewDisjointSet[int]()
+		label := make([]int, size)
+		for idx := range label {
+			label[idx] = idx
+			d.Add(idx)
+		}
+		for edges := r.Intn(size); edges > 0; edges-- {
+			a, b := r.Intn(size), r.Intn(size)
+			d.Union(a, b)
+			from, to := label[b], label[a]
+			for idx := range label {
+				if label[idx] == from {
+					label[idx] = to
+				}
+			}
+		}
+
+		components := map[int]bool{}
+		for a := 0; a < size; a++ {
+			components[label[a]] = true
+			for b := 0; b < size; b++ {
+				if d.Connected(a, b) != (label[a] == label[b]) {
+					t.Fatalf("Expected connected(%d, %d) to be %t", a, b, label[a] == label[b])
+				}
+			}
+		}
+		if d.Count() != len(components) || len(d.Components()) != len(components) {
+			t.Fatalf("Expected %d components, but got %d", len(components), d.Count())
+		}
+	}
+}
+
+func BenchmarkDisjointSetUnion(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pairs := make([][2]int, 100000)
+	for idx := range pairs {
+		pairs[idx] = [2]int{r.Intn(100000), r.Intn(100000)}
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		d := NewDisjointSet[int]()
+		for _, pair := range pairs {
+			d.Union(pair[0], pair[1])
+		}
+	}
+}
+
+func BenchmarkDisjointSetFind(b *testing.B) {
+	d := NewDisjointSet[int]()
+	for idx := 1; idx < 100000; idx++ {
+		d.Union(idx-1, idx)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		d.Find(n % 100000)
+	}
+}